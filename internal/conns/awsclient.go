@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	aws_sdkv2 "github.com/aws/aws-sdk-go-v2/aws"
 	aws_sdkv1 "github.com/aws/aws-sdk-go/aws"
@@ -24,6 +25,9 @@ type AWSClient struct {
 	AccountID               string
 	DefaultTagsConfig       *tftags.DefaultConfig
 	DNSSuffix               string
+	IAMPropagationTimeout   time.Duration
+	IAMRoleAuditLog         string
+	IAMRoleDeletionTag      string
 	IgnoreTagsConfig        *tftags.IgnoreConfig
 	MediaConvertAccountConn *mediaconvert_sdkv1.MediaConvert
 	Partition               string
@@ -6,6 +6,7 @@ package conns
 import (
 	"context"
 	"fmt"
+	"time"
 
 	aws_sdkv2 "github.com/aws/aws-sdk-go-v2/aws"
 	imds_sdkv2 "github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
@@ -34,6 +35,9 @@ type Config struct {
 	Endpoints                      map[string]string
 	ForbiddenAccountIds            []string
 	HTTPProxy                      string
+	IAMPropagationTimeout          time.Duration
+	IAMRoleAuditLog                string
+	IAMRoleDeletionTag             string
 	IgnoreTagsConfig               *tftags.IgnoreConfig
 	Insecure                       bool
 	MaxRetries                     int
@@ -190,6 +194,9 @@ func (c *Config) ConfigureProvider(ctx context.Context, client *AWSClient) (*AWS
 	client.AccountID = accountID
 	client.DefaultTagsConfig = c.DefaultTagsConfig
 	client.DNSSuffix = DNSSuffix
+	client.IAMPropagationTimeout = c.IAMPropagationTimeout
+	client.IAMRoleAuditLog = c.IAMRoleAuditLog
+	client.IAMRoleDeletionTag = c.IAMRoleDeletionTag
 	client.IgnoreTagsConfig = c.IgnoreTagsConfig
 	client.Partition = partition
 	client.Region = c.Region
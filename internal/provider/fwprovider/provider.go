@@ -71,6 +71,18 @@ func (p *fwprovider) Schema(ctx context.Context, req provider.SchemaRequest, res
 				Optional:    true,
 				Description: "The address of an HTTP proxy to use when accessing the AWS API. Can also be configured using the `HTTP_PROXY` or `HTTPS_PROXY` environment variables.",
 			},
+			"iam_propagation_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for IAM changes to propagate, as a duration such as `5m`.\nDefaults to `2m`. Increase it where IAM is slow to become consistent.",
+			},
+			"iam_role_audit_log": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a local file to which a JSON record of every IAM role create, update and delete\nis appended. Use `-` to write the records to the provider log.",
+			},
+			"iam_role_deletion_protection_tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Tag key that protects an IAM role from deletion. A role with this tag set to `true`\ncannot be destroyed until the tag is removed.",
+			},
 			"insecure": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted, default value is `false`",
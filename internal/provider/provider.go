@@ -97,6 +97,24 @@ func New(ctx context.Context) (*schema.Provider, error) {
 				Description: "The address of an HTTP proxy to use when accessing the AWS API. " +
 					"Can also be configured using the `HTTP_PROXY` or `HTTPS_PROXY` environment variables.",
 			},
+			"iam_propagation_timeout": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "How long to wait for IAM changes to propagate, as a duration such as `5m`.\n" +
+					"Defaults to `2m`. Increase it where IAM is slow to become consistent.",
+			},
+			"iam_role_audit_log": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of a local file to which a JSON record of every IAM role create, update and delete\n" +
+					"is appended. Use `-` to write the records to the provider log.",
+			},
+			"iam_role_deletion_protection_tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Tag key that protects an IAM role from deletion. A role with this tag set to `true`\n" +
+					"cannot be destroyed until the tag is removed.",
+			},
 			"ignore_tags": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -454,6 +472,8 @@ func configure(ctx context.Context, provider *schema.Provider, d *schema.Resourc
 		EC2MetadataServiceEndpointMode: d.Get("ec2_metadata_service_endpoint_mode").(string),
 		Endpoints:                      make(map[string]string),
 		HTTPProxy:                      d.Get("http_proxy").(string),
+		IAMRoleAuditLog:                d.Get("iam_role_audit_log").(string),
+		IAMRoleDeletionTag:             d.Get("iam_role_deletion_protection_tag").(string),
 		Insecure:                       d.Get("insecure").(bool),
 		MaxRetries:                     25, // Set default here, not in schema (muxing with v6 provider).
 		Profile:                        d.Get("profile").(string),
@@ -522,6 +542,17 @@ func configure(ctx context.Context, provider *schema.Provider, d *schema.Resourc
 		config.IgnoreTagsConfig = expandIgnoreTags(ctx, v.([]interface{})[0].(map[string]interface{}))
 	}
 
+	if v, ok := d.GetOk("iam_propagation_timeout"); ok {
+		timeout, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, sdkdiag.AppendErrorf(diags, "parsing iam_propagation_timeout (%s): %s", v, err)
+		}
+		if timeout <= 0 {
+			return nil, sdkdiag.AppendErrorf(diags, "iam_propagation_timeout (%s) must be positive", v)
+		}
+		config.IAMPropagationTimeout = timeout
+	}
+
 	if v, ok := d.GetOk("max_retries"); ok {
 		config.MaxRetries = v.(int)
 	}
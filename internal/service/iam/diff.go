@@ -5,7 +5,9 @@ package iam
 
 import (
 	"net/url"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,3 +26,69 @@ func suppressOpenIDURL(k, old, new string, d *schema.ResourceData) bool {
 
 	return oldUrl.String() == newUrl.String()
 }
+
+// suppressEquivalentPolicyARN suppresses differences between IAM managed policy ARNs that identify the same policy.
+// Policy names are unique (case-insensitively) within an account regardless of path,
+// so ARNs in the same partition and account whose policy names match are equivalent.
+func suppressEquivalentPolicyARN(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	// Never suppress adding or removing a policy.
+	if old == "" || new == "" {
+		return false
+	}
+
+	oldARN, err := arn.Parse(old)
+	if err != nil {
+		return false
+	}
+
+	newARN, err := arn.Parse(new)
+	if err != nil {
+		return false
+	}
+
+	if oldARN.Partition != newARN.Partition || oldARN.Service != newARN.Service || oldARN.AccountID != newARN.AccountID {
+		return false
+	}
+
+	oldName, ok := policyNameFromARNResource(oldARN.Resource)
+	if !ok {
+		return false
+	}
+
+	newName, ok := policyNameFromARNResource(newARN.Resource)
+	if !ok {
+		return false
+	}
+
+	return strings.EqualFold(oldName, newName)
+}
+
+func policyNameFromARNResource(resource string) (string, bool) {
+	parts := strings.Split(resource, ARNSeparator)
+
+	if len(parts) < 2 || parts[0] != "policy" || parts[len(parts)-1] == "" {
+		return "", false
+	}
+
+	return parts[len(parts)-1], true
+}
+
+// policyARNKey returns a normalized key identifying the managed policy with the specified ARN.
+// The partition and path are ignored and the policy name is compared case-insensitively.
+func policyARNKey(policyARN string) string {
+	parsedARN, err := arn.Parse(policyARN)
+	if err != nil {
+		return policyARN
+	}
+
+	name, ok := policyNameFromARNResource(parsedARN.Resource)
+	if !ok {
+		return policyARN
+	}
+
+	return strings.Join([]string{parsedARN.Service, parsedARN.AccountID, strings.ToLower(name)}, ":")
+}
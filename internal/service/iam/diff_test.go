@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"testing"
+)
+
+func TestSuppressEquivalentPolicyARN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{
+			name: "both empty",
+			want: true,
+		},
+		{
+			name: "identical",
+			old:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+			want: true,
+		},
+		{
+			name: "different path",
+			old:  "arn:aws:iam::123456789012:policy/boundaries/Boundary", // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/Boundary",            // lintignore:AWSAT005
+			want: true,
+		},
+		{
+			name: "different name case",
+			old:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			want: true,
+		},
+		{
+			name: "removal",
+			old:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+		},
+		{
+			name: "addition",
+			new:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+		},
+		{
+			name: "different name",
+			old:  "arn:aws:iam::123456789012:policy/Boundary",  // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/Boundary2", // lintignore:AWSAT005
+		},
+		{
+			name: "different account",
+			old:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+			new:  "arn:aws:iam::210987654321:policy/Boundary", // lintignore:AWSAT005
+		},
+		{
+			name: "different partition",
+			old:  "arn:aws:iam::123456789012:policy/Boundary",        // lintignore:AWSAT005
+			new:  "arn:aws-us-gov:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+		},
+		{
+			name: "AWS managed and customer managed",
+			old:  "arn:aws:iam::aws:policy/PowerUserAccess",          // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/PowerUserAccess", // lintignore:AWSAT005
+		},
+		{
+			name: "not a policy",
+			old:  "arn:aws:iam::123456789012:role/Boundary",   // lintignore:AWSAT005
+			new:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+		},
+		{
+			name: "invalid",
+			old:  "Boundary",
+			new:  "arn:aws:iam::123456789012:policy/Boundary", // lintignore:AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := suppressEquivalentPolicyARN("permissions_boundary", testCase.old, testCase.new, nil); got != testCase.want {
+				t.Errorf("suppressEquivalentPolicyARN(%q, %q) = %t, want %t", testCase.old, testCase.new, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestPolicyARNKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		arn1      string
+		arn2      string
+		duplicate bool
+	}{
+		{
+			name:      "different partition",
+			arn1:      "arn:aws:iam::aws:policy/ReadOnlyAccess",        // lintignore:AWSAT005
+			arn2:      "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess", // lintignore:AWSAT005
+			duplicate: true,
+		},
+		{
+			name:      "different path",
+			arn1:      "arn:aws:iam::123456789012:policy/Policy",         // lintignore:AWSAT005
+			arn2:      "arn:aws:iam::123456789012:policy/project/Policy", // lintignore:AWSAT005
+			duplicate: true,
+		},
+		{
+			name:      "different name case",
+			arn1:      "arn:aws:iam::123456789012:policy/Policy", // lintignore:AWSAT005
+			arn2:      "arn:aws:iam::123456789012:policy/POLICY", // lintignore:AWSAT005
+			duplicate: true,
+		},
+		{
+			name: "different name",
+			arn1: "arn:aws:iam::123456789012:policy/Policy1", // lintignore:AWSAT005
+			arn2: "arn:aws:iam::123456789012:policy/Policy2", // lintignore:AWSAT005
+		},
+		{
+			name: "different account",
+			arn1: "arn:aws:iam::aws:policy/ReadOnlyAccess",          // lintignore:AWSAT005
+			arn2: "arn:aws:iam::123456789012:policy/ReadOnlyAccess", // lintignore:AWSAT005
+		},
+		{
+			name: "invalid",
+			arn1: "ReadOnlyAccess",
+			arn2: "arn:aws:iam::aws:policy/ReadOnlyAccess", // lintignore:AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := policyARNKey(testCase.arn1) == policyARNKey(testCase.arn2); got != testCase.duplicate {
+				t.Errorf("policyARNKey(%q) == policyARNKey(%q) is %t, want %t", testCase.arn1, testCase.arn2, got, testCase.duplicate)
+			}
+		})
+	}
+}
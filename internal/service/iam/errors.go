@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operations on an IAM role reported by RoleError.
+const (
+	RoleOperationCreate = "create"
+	RoleOperationRead   = "read"
+	RoleOperationUpdate = "update"
+	RoleOperationDelete = "delete"
+)
+
+// RoleError is an error from an operation on an IAM role.
+// Use errors.As to get the operation and role name, and errors.Is or errors.As to match the underlying error.
+type RoleError struct {
+	Operation string
+	RoleName  string
+	Err       error
+}
+
+func newRoleError(operation, roleName string, err error) *RoleError {
+	return &RoleError{
+		Operation: operation,
+		RoleName:  roleName,
+		Err:       err,
+	}
+}
+
+func (e *RoleError) Error() string {
+	var verb string
+	switch e.Operation {
+	case RoleOperationCreate:
+		verb = "creating"
+	case RoleOperationRead:
+		verb = "reading"
+	case RoleOperationUpdate:
+		verb = "updating"
+	case RoleOperationDelete:
+		verb = "deleting"
+	default:
+		verb = e.Operation
+	}
+
+	return fmt.Sprintf("%s IAM Role (%s): %s", verb, e.RoleName, e.Err)
+}
+
+func (e *RoleError) Unwrap() error {
+	return e.Err
+}
+
+// MalformedPolicyDocumentError is a MalformedPolicyDocument error from IAM with the problems
+// found by checking the policy document locally, as the message from IAM often does not say which part
+// of the document is wrong.
+type MalformedPolicyDocumentError struct {
+	Problems []string
+	Err      error
+}
+
+func (e *MalformedPolicyDocumentError) Error() string {
+	return fmt.Sprintf("%s; problems found in the policy document: %s", e.Err, strings.Join(e.Problems, "; "))
+}
+
+func (e *MalformedPolicyDocumentError) Unwrap() error {
+	return e.Err
+}
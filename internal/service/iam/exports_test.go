@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+// Exports for use in tests only.
+var (
+	AdoptRole                               = adoptRole
+	AppendRoleBoundaryCappedPolicyWarnings  = appendRoleBoundaryCappedPolicyWarnings
+	AppendRoleExternalManagedPolicyWarnings = appendRoleExternalManagedPolicyWarnings
+	AttachRoleManagedPolicies               = attachRoleManagedPolicies
+	CheckRoleExistingInlinePolicies         = checkRoleExistingInlinePolicies
+	CheckRolePermissionsBoundaryExists      = checkRolePermissionsBoundaryExists
+	CreateRoleInstanceProfile               = createRoleInstanceProfile
+	DecodeRoleAssumeRolePolicy              = decodeRoleAssumeRolePolicy
+	DeleteRoleInlinePolicies                = deleteRoleInlinePolicies
+	DeleteRoleInstanceProfile               = deleteRoleInstanceProfile
+	DeleteRoleInstanceProfiles              = deleteRoleInstanceProfiles
+	DeleteRolePolicyAttachments             = deleteRolePolicyAttachments
+	ExpandRoleDescription                   = expandRoleDescription
+	ExpandRoleAssumeRolePolicy              = expandRoleAssumeRolePolicy
+	ExpandRoleManagedPolicyNames            = expandRoleManagedPolicyNames
+	ExpandRoleTrustedServicesPolicy         = expandRoleTrustedServicesPolicy
+	ExpandRoleInlinePoliciesMapChanges      = expandRoleInlinePoliciesMapChanges
+	FindRoleByNameRetryingThrottling        = findRoleByNameRetryingThrottling
+	FlattenRoleInlinePoliciesMap            = flattenRoleInlinePoliciesMap
+	FlattenRoleTrustRelationshipStatements  = flattenRoleTrustRelationshipStatements
+	ForEachRoleManagedPolicy                = forEachRoleManagedPolicy
+	IsRoleDetachRetryable                   = isRoleDetachRetryable
+	MergeRoleAssumeRolePolicyStatements     = mergeRoleAssumeRolePolicyStatements
+	NormalizeRoleInlinePolicies             = normalizeRoleInlinePolicies
+	ParseRoleARN                            = parseRoleARN
+	PutRoleInlinePolicies                   = putRoleInlinePolicies
+	ReadRoleInlinePolicies                  = readRoleInlinePolicies
+	ReadRolePermissionsBoundaryDocument     = readRolePermissionsBoundaryDocument
+	ReadRolePolicyAttachments               = readRolePolicyAttachments
+	ReadRolePolicyNames                     = readRolePolicyNames
+	RefreshRoleInlinePolicies               = refreshRoleInlinePolicies
+	RemoveRoleAutoTags                      = removeRoleAutoTags
+	RoleAssumeRolePolicyError               = roleAssumeRolePolicyError
+	RoleAssumeRolePolicyProblems            = roleAssumeRolePolicyProblems
+	RoleDeletionProtected                   = roleDeletionProtected
+	RoleDeletionProtectionTagKey            = roleDeletionProtectionTagKey
+	RoleHasTagKeys                          = roleHasTagKeys
+	RoleIgnoreTagsConfig                    = roleIgnoreTagsConfig
+	RoleManagedInlinePolicyNames            = roleManagedInlinePolicyNames
+	RoleManagedPoliciesExceedingBoundary    = roleManagedPoliciesExceedingBoundary
+	RoleImportedManagedPolicyDetachments    = roleImportedManagedPolicyDetachments
+	RoleIsServiceLinked                     = roleIsServiceLinked
+	RoleNameFromARN                         = roleNameFromARN
+	RolePermissionsBoundaryName             = rolePermissionsBoundaryName
+	RolePermissionsBoundaryUpdate           = rolePermissionsBoundaryUpdate
+	RolePropagationTimeout                  = rolePropagationTimeout
+	RetryCreateRole                         = retryCreateRole
+	RoleAutoTags                            = roleAutoTags
+	RoleExternalInlinePolicyNames           = roleExternalInlinePolicyNames
+	RoleReadSkipsPolicies                   = roleReadSkipsPolicies
+	RunRoleUpdates                          = runRoleUpdates
+	SplitRoleAssumeRolePolicyStatements     = splitRoleAssumeRolePolicyStatements
+	SplitRoleExternalTags                   = splitRoleExternalTags
+	TrimRoleTags                            = trimRoleTags
+	UntrimRoleTags                          = untrimRoleTags
+	UpdateRoleManagedPolicies               = updateRoleManagedPolicies
+	WaitRoleAssumeRolePolicyPropagated      = waitRoleAssumeRolePolicyPropagated
+	WaitRoleDeleted                         = waitRoleDeleted
+)
@@ -37,7 +37,7 @@ func DataSourceInstanceProfiles() *schema.Resource {
 			"role_name": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validResourceName(roleNameMaxLen),
+				ValidateFunc: validRoleName(roleNameMaxLen),
 			},
 		},
 	}
@@ -444,6 +444,23 @@ func findPolicyVersion(ctx context.Context, conn *iam.IAM, arn, versionID string
 	return output.PolicyVersion, nil
 }
 
+// findPolicyDocumentByARN returns the decoded document of the default version of the specified managed policy.
+func findPolicyDocumentByARN(ctx context.Context, conn *iam.IAM, arn string) (string, error) {
+	policy, err := FindPolicyByARN(ctx, conn, arn)
+
+	if err != nil {
+		return "", err
+	}
+
+	policyVersion, err := findPolicyVersion(ctx, conn, arn, aws.StringValue(policy.DefaultVersionId))
+
+	if err != nil {
+		return "", err
+	}
+
+	return url.QueryUnescape(aws.StringValue(policyVersion.Document))
+}
+
 func findPolicyVersionsByARN(ctx context.Context, conn *iam.IAM, arn string) ([]*iam.PolicyVersion, error) {
 	input := &iam.ListPolicyVersionsInput{
 		PolicyArn: aws.String(arn),
@@ -4,14 +4,18 @@
 package iam
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/jmespath/go-jmespath"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -155,7 +159,11 @@ func (ps *IAMPolicyStatementPrincipalSet) UnmarshalJSON(b []byte) error {
 			case []interface{}:
 				values := []string{}
 				for _, v := range value.([]interface{}) {
-					values = append(values, v.(string))
+					sv, ok := v.(string)
+					if !ok {
+						return fmt.Errorf("Unsupported data type %T for IAMPolicyStatementPrincipalSet.Identifiers", v)
+					}
+					values = append(values, sv)
 				}
 				out = append(out, IAMPolicyStatementPrincipal{Type: key, Identifiers: values})
 			default:
@@ -212,7 +220,11 @@ func (cs *IAMPolicyStatementConditionSet) UnmarshalJSON(b []byte) error {
 			case []interface{}:
 				values := []string{}
 				for _, v := range var_values {
-					values = append(values, v.(string))
+					sv, ok := v.(string)
+					if !ok {
+						return fmt.Errorf("Unsupported data type %T for IAMPolicyStatementConditionSet.Values", v)
+					}
+					values = append(values, sv)
 				}
 				out = append(out, IAMPolicyStatementCondition{Test: test_key, Variable: var_key, Values: values})
 			}
@@ -288,3 +300,475 @@ func isValidPolicyAWSPrincipal(principal string) bool { // nosemgrep:ci.aws-in-f
 	}
 	return false
 }
+
+// policyStatementValues returns the string values of a policy statement element
+// such as Action or Resource, which may be either a single string or a list of strings.
+func policyStatementValues(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, v := range v {
+			if v, ok := v.(string); ok {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+// policyAllowsFullIAMAccess returns true if any Allow statement in an IAM policy
+// grants wildcard IAM actions on all resources.
+//
+// A statement matches if its actions include `*` or any IAM action containing a wildcard
+// (e.g. `iam:*` or `iam:*Role*`), or if it uses `NotAction` without excluding all IAM actions,
+// and its resources include `*` or an ARN covering all IAM resources (e.g. `arn:aws:iam::*:*`)
+// or it uses `NotResource`. Conditions are not evaluated.
+func policyAllowsFullIAMAccess(policy string) (bool, error) {
+	var doc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return false, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	for _, statement := range doc.Statements {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		if statement.NotResources == nil && !slices.ContainsFunc(policyStatementValues(statement.Resources), policyResourceCoversAllIAM) {
+			continue
+		}
+
+		if statement.NotActions != nil {
+			if !slices.ContainsFunc(policyStatementValues(statement.NotActions), policyActionCoversAllIAM) {
+				return true, nil
+			}
+
+			continue
+		}
+
+		if slices.ContainsFunc(policyStatementValues(statement.Actions), policyActionIsIAMWildcard) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// policyActionIsIAMWildcard returns true if the action is `*` or a wildcard IAM action.
+func policyActionIsIAMWildcard(action string) bool {
+	if action == "*" {
+		return true
+	}
+
+	service, name, ok := strings.Cut(action, ":")
+	if !ok {
+		return false
+	}
+
+	return policyWildcardMatch(service, "iam") && strings.ContainsAny(name, "*?")
+}
+
+// policyActionCoversAllIAM returns true if the action matches every IAM action.
+func policyActionCoversAllIAM(action string) bool {
+	if action == "*" {
+		return true
+	}
+
+	service, name, ok := strings.Cut(action, ":")
+	if !ok {
+		return false
+	}
+
+	return policyWildcardMatch(service, "iam") && strings.Trim(name, "*") == ""
+}
+
+// policyResourceCoversAllIAM returns true if the resource matches every IAM resource.
+func policyResourceCoversAllIAM(resource string) bool {
+	if resource == "*" {
+		return true
+	}
+
+	// arn:PARTITION:SERVICE:REGION:ACCOUNT:RESOURCE
+	parts := strings.SplitN(resource, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return false
+	}
+
+	// Any account is accepted, as a role can only manage IAM resources in its own account.
+	return policyWildcardMatch(parts[2], "iam") && strings.Trim(parts[5], "*") == ""
+}
+
+// policyWildcardMatch returns true if the value matches the pattern, in which
+// `*` matches any sequence of characters and `?` matches any single character.
+// Matching is case-insensitive.
+func policyWildcardMatch(pattern, value string) bool {
+	var sb strings.Builder
+
+	sb.WriteString("(?i)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+
+	return regexp.MustCompile(sb.String()).MatchString(value)
+}
+
+// policyActionsExceedingBoundary returns, sorted, the actions allowed by a policy that a permissions boundary does
+// not allow, either because no Allow statement in the boundary covers them or because a Deny statement overlaps them.
+// An Allow statement using `NotAction` in the policy is treated as allowing `*`.
+//
+// The comparison is approximate: only actions are compared, and resources and conditions are not evaluated.
+func policyActionsExceedingBoundary(policy, boundary string) ([]string, error) {
+	var policyDoc, boundaryDoc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &policyDoc); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(boundary), &boundaryDoc); err != nil {
+		return nil, fmt.Errorf("parsing permissions boundary: %w", err)
+	}
+
+	exceeding := make(map[string]struct{})
+
+	for _, statement := range policyDoc.Statements {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		actions := policyStatementValues(statement.Actions)
+		if statement.NotActions != nil {
+			actions = []string{"*"}
+		}
+
+		for _, action := range actions {
+			if !policyBoundaryAllowsAction(boundaryDoc, action) || policyBoundaryDeniesAction(boundaryDoc, action) {
+				exceeding[action] = struct{}{}
+			}
+		}
+	}
+
+	actions := make([]string, 0, len(exceeding))
+	for action := range exceeding {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	return actions, nil
+}
+
+// policyBoundaryAllowsAction returns true if an Allow statement in the boundary covers every action matched by action.
+func policyBoundaryAllowsAction(boundary IAMPolicyDoc, action string) bool {
+	for _, statement := range boundary.Statements {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		if statement.NotActions != nil {
+			if !slices.ContainsFunc(policyStatementValues(statement.NotActions), func(pattern string) bool { return policyActionsOverlap(pattern, action) }) {
+				return true
+			}
+
+			continue
+		}
+
+		if slices.ContainsFunc(policyStatementValues(statement.Actions), func(pattern string) bool { return policyWildcardMatch(pattern, action) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// policyBoundaryDeniesAction returns true if a Deny statement in the boundary matches any action matched by action.
+func policyBoundaryDeniesAction(boundary IAMPolicyDoc, action string) bool {
+	for _, statement := range boundary.Statements {
+		if !strings.EqualFold(statement.Effect, "Deny") {
+			continue
+		}
+
+		if statement.NotActions != nil {
+			if !slices.ContainsFunc(policyStatementValues(statement.NotActions), func(pattern string) bool { return policyWildcardMatch(pattern, action) }) {
+				return true
+			}
+
+			continue
+		}
+
+		if slices.ContainsFunc(policyStatementValues(statement.Actions), func(pattern string) bool { return policyActionsOverlap(pattern, action) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// policyActionsOverlap returns true if either action pattern matches the other, which approximates
+// whether any action is matched by both.
+func policyActionsOverlap(a, b string) bool {
+	return policyWildcardMatch(a, b) || policyWildcardMatch(b, a)
+}
+
+// policyTrustsOrganization returns true if any Allow statement in a role trust policy
+// is scoped to an AWS Organization via the `aws:PrincipalOrgID` or `aws:PrincipalOrgPaths`
+// condition keys, making the role assumable from anywhere in the organization.
+func policyTrustsOrganization(policy string) (bool, error) {
+	var doc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return false, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	for _, statement := range doc.Statements {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		for _, condition := range statement.Conditions {
+			if strings.EqualFold(condition.Variable, "aws:PrincipalOrgID") || strings.EqualFold(condition.Variable, "aws:PrincipalOrgPaths") {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// policyAllowedPrincipals returns the principals in the Allow statements of a policy, one entry per principal type,
+// sorted by type with sorted, unique identifiers. A "*" principal is returned as the equivalent {"AWS": "*"}.
+func policyAllowedPrincipals(policy string) ([]IAMPolicyStatementPrincipal, error) {
+	var doc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	identifiers := make(map[string]map[string]struct{})
+
+	for _, statement := range doc.Statements {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		for _, principal := range statement.Principals {
+			var values []string
+
+			switch v := principal.Identifiers.(type) {
+			case string:
+				values = []string{v}
+			case []string:
+				values = v
+			}
+
+			typ := principal.Type
+			if typ == "*" {
+				typ = "AWS"
+			}
+
+			if identifiers[typ] == nil {
+				identifiers[typ] = make(map[string]struct{})
+			}
+
+			for _, v := range values {
+				identifiers[typ][v] = struct{}{}
+			}
+		}
+	}
+
+	principals := make([]IAMPolicyStatementPrincipal, 0, len(identifiers))
+
+	for typ, values := range identifiers {
+		ids := make([]string, 0, len(values))
+		for v := range values {
+			ids = append(ids, v)
+		}
+		sort.Strings(ids)
+
+		principals = append(principals, IAMPolicyStatementPrincipal{Type: typ, Identifiers: ids})
+	}
+
+	sort.Slice(principals, func(i, j int) bool {
+		return principals[i].Type < principals[j].Type
+	})
+
+	return principals, nil
+}
+
+// policyCanonicalStatement is a policy statement in the canonical form used by policyCanonicalHash.
+type policyCanonicalStatement struct {
+	Sid          string                         `json:",omitempty"`
+	Effect       string                         `json:",omitempty"`
+	Action       []string                       `json:",omitempty"`
+	NotAction    []string                       `json:",omitempty"`
+	Resource     []string                       `json:",omitempty"`
+	NotResource  []string                       `json:",omitempty"`
+	Principal    interface{}                    `json:",omitempty"`
+	NotPrincipal interface{}                    `json:",omitempty"`
+	Condition    map[string]map[string][]string `json:",omitempty"`
+}
+
+// policyCanonicalHash returns the hex-encoded SHA-256 hash of a canonical form of a policy.
+// The canonical form ignores the same differences as the policy diff suppression (awspolicyequivalence):
+// whitespace, key order, the order of statements and of values within an element, single values
+// written as lists, the case of Effect, and an account ID principal written as its root user ARN.
+func policyCanonicalHash(policy string) (string, error) {
+	policy = strings.TrimSpace(policy)
+	if strings.HasPrefix(policy, "[") && strings.HasSuffix(policy, "]") {
+		policy = strings.TrimSpace(strings.TrimPrefix(strings.TrimSuffix(policy, "]"), "["))
+	}
+	if policy == "" {
+		policy = "{}"
+	}
+
+	var doc struct {
+		Version   string
+		Id        string
+		Statement interface{}
+	}
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return "", fmt.Errorf("parsing policy: %w", err)
+	}
+
+	var rawStatements []interface{}
+	switch v := doc.Statement.(type) {
+	case nil:
+	case []interface{}:
+		rawStatements = v
+	case map[string]interface{}:
+		rawStatements = []interface{}{v}
+	default:
+		return "", fmt.Errorf("parsing policy: unsupported data type %T for Statement", v)
+	}
+
+	statements := make([]string, 0, len(rawStatements))
+	for _, v := range rawStatements {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("parsing policy: unsupported data type %T for Statement", v)
+		}
+
+		sid, _ := m["Sid"].(string)
+		effect, _ := m["Effect"].(string)
+		statement := policyCanonicalStatement{
+			Sid:          sid,
+			Effect:       strings.ToLower(effect),
+			Action:       policyCanonicalValues(m["Action"]),
+			NotAction:    policyCanonicalValues(m["NotAction"]),
+			Resource:     policyCanonicalValues(m["Resource"]),
+			NotResource:  policyCanonicalValues(m["NotResource"]),
+			Principal:    policyCanonicalPrincipals(m["Principal"]),
+			NotPrincipal: policyCanonicalPrincipals(m["NotPrincipal"]),
+		}
+
+		if conditions, ok := m["Condition"].(map[string]interface{}); ok && len(conditions) > 0 {
+			statement.Condition = make(map[string]map[string][]string, len(conditions))
+			for test, v := range conditions {
+				statement.Condition[test] = make(map[string][]string)
+				if variables, ok := v.(map[string]interface{}); ok {
+					for variable, values := range variables {
+						statement.Condition[test][variable] = policyCanonicalValues(values)
+					}
+				}
+			}
+		}
+
+		b, err := json.Marshal(statement)
+		if err != nil {
+			return "", err
+		}
+
+		statements = append(statements, string(b))
+	}
+
+	// Statement order is not significant.
+	sort.Strings(statements)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%q\n%q\n", doc.Version, doc.Id)
+	for _, statement := range statements {
+		fmt.Fprintln(h, statement)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// policyCanonicalValues returns the sorted string values of a policy element that may be a single value or a list.
+// Boolean and numeric values are formatted as strings.
+func policyCanonicalValues(v interface{}) []string {
+	var values []string
+
+	add := func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			values = append(values, v)
+		case bool:
+			values = append(values, strconv.FormatBool(v))
+		case float64:
+			values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+
+	switch v := v.(type) {
+	case []interface{}:
+		for _, v := range v {
+			add(v)
+		}
+	default:
+		add(v)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// policyCanonicalPrincipals returns the canonical form of a Principal or NotPrincipal element.
+// A string principal (i.e. "*") is returned unchanged; a map of principal types has empty types removed
+// and each type's identifiers sorted, with an account's root user ARN replaced by the account ID.
+func policyCanonicalPrincipals(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		principals := make(map[string][]string)
+
+		for typ, v := range v {
+			identifiers := policyCanonicalValues(v)
+			if len(identifiers) == 0 {
+				continue
+			}
+
+			for i, identifier := range identifiers {
+				if parsedARN, err := arn.Parse(identifier); err == nil && parsedARN.Service == "iam" && parsedARN.Resource == "root" {
+					identifiers[i] = parsedARN.AccountID
+				}
+			}
+			sort.Strings(identifiers)
+
+			principals[typ] = identifiers
+		}
+
+		if len(principals) == 0 {
+			return nil
+		}
+
+		return principals
+	}
+
+	return nil
+}
@@ -5,8 +5,10 @@ package iam
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
+	awspolicy "github.com/hashicorp/awspolicyequivalence"
 	"github.com/hashicorp/terraform-provider-aws/internal/errs"
 )
 
@@ -265,3 +267,392 @@ func TestIsValidAWSPrincipal(t *testing.T) { // nosemgrep:ci.aws-in-func-name
 		})
 	}
 }
+
+func TestPolicyAllowsFullIAMAccess(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		json     string
+		expected bool
+	}{
+		"iam_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","Resource":"*"}]}`,
+			expected: true,
+		},
+		"full_wildcard_list": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","*"],"Resource":["*"]}]}`,
+			expected: true,
+		},
+		"deny": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"iam:*","Resource":"*"}]}`,
+			expected: false,
+		},
+		"scoped_resource": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","Resource":"arn:aws:iam::123456789012:role/test"}]}`, // lintignore:AWSAT005
+			expected: false,
+		},
+		"scoped_action": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:GetRole","Resource":"*"}]}`,
+			expected: false,
+		},
+		"partial_iam_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*Role*","Resource":"*"}]}`,
+			expected: true,
+		},
+		"service_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"i*:*","Resource":"*"}]}`,
+			expected: true,
+		},
+		"other_service_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,
+			expected: false,
+		},
+		"iam_arn_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","Resource":"arn:aws:iam::*:*"}]}`, // lintignore:AWSAT005
+			expected: true,
+		},
+		"iam_account_arn_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","Resource":"arn:*:iam::123456789012:*"}]}`,
+			expected: true,
+		},
+		"not_action": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":"s3:*","Resource":"*"}]}`,
+			expected: true,
+		},
+		"not_action_excludes_iam": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":["iam:*","organizations:*"],"Resource":"*"}]}`,
+			expected: false,
+		},
+		"not_resource": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","NotResource":"arn:aws:iam::123456789012:role/admin"}]}`, // lintignore:AWSAT005
+			expected: true,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyAllowsFullIAMAccess(testcase.json)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testcase.expected {
+				t.Errorf("expected %t, got %t", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPolicyActionsExceedingBoundary(t *testing.T) {
+	t.Parallel()
+
+	// A narrow boundary allowing only S3 reads, and never deleting buckets.
+	boundary := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*","s3:List*"],"Resource":"*"},{"Effect":"Deny","Action":"s3:*Bucket","Resource":"*"}]}`
+
+	testcases := map[string]struct {
+		json     string
+		boundary string
+		expected []string
+	}{
+		"within": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:ListAllMyBuckets"],"Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{},
+		},
+		"broad": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:*","ec2:DescribeInstances","s3:GetObject"],"Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{"ec2:DescribeInstances", "s3:*"},
+		},
+		"narrower_wildcard": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject*","Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{},
+		},
+		"denied": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetBucket","Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{"s3:GetBucket"},
+		},
+		"deny_statement_ignored": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"iam:*","Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{},
+		},
+		"not_action": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":"iam:*","Resource":"*"}]}`,
+			boundary: boundary,
+			expected: []string{"*"},
+		},
+		"boundary_not_action": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","iam:CreateRole"],"Resource":"*"}]}`,
+			boundary: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":"iam:*","Resource":"*"}]}`,
+			expected: []string{"iam:CreateRole"},
+		},
+		"boundary_deny_not_action": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","ec2:RunInstances"],"Resource":"*"}]}`,
+			boundary: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","NotAction":"s3:*","Resource":"*"}]}`,
+			expected: []string{"ec2:RunInstances"},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyActionsExceedingBoundary(testcase.json, testcase.boundary)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testcase.expected) {
+				t.Errorf("expected %v, got %v", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPolicyTrustsOrganization(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		json     string
+		expected bool
+	}{
+		"org_id_condition": {
+			json: `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"AWS": "*"},
+    "Action": "sts:AssumeRole",
+    "Condition": {"StringEquals": {"aws:PrincipalOrgID": "o-abcdef1234"}}
+  }]
+}`,
+			expected: true,
+		},
+		"org_paths_condition": {
+			json: `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"AWS": "*"},
+    "Action": "sts:AssumeRole",
+    "Condition": {"ForAnyValue:StringLike": {"aws:PrincipalOrgPaths": ["o-abcdef1234/r-ab12/*"]}}
+  }]
+}`,
+			expected: true,
+		},
+		"service_principal": {
+			json: `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "ec2.amazonaws.com"},
+    "Action": "sts:AssumeRole"
+  }]
+}`,
+			expected: false,
+		},
+		"deny_org_condition": {
+			json: `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Deny",
+    "Principal": {"AWS": "*"},
+    "Action": "sts:AssumeRole",
+    "Condition": {"StringNotEquals": {"aws:PrincipalOrgID": "o-abcdef1234"}}
+  }]
+}`,
+			expected: false,
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyTrustsOrganization(testcase.json)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testcase.expected {
+				t.Errorf("expected %t, got %t", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPolicyAllowedPrincipals(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		json     string
+		expected []IAMPolicyStatementPrincipal
+	}{
+		"service_string": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			expected: []IAMPolicyStatementPrincipal{
+				{Type: "Service", Identifiers: []string{"ec2.amazonaws.com"}},
+			},
+		},
+		"aws_array": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","111122223333"]},"Action":"sts:AssumeRole"}]}`, // lintignore:AWSAT005
+			expected: []IAMPolicyStatementPrincipal{
+				{Type: "AWS", Identifiers: []string{"111122223333", "arn:aws:iam::123456789012:root"}}, // lintignore:AWSAT005
+			},
+		},
+		"federated": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Federated":"cognito-identity.amazonaws.com"},"Action":"sts:AssumeRoleWithWebIdentity"}]}`,
+			expected: []IAMPolicyStatementPrincipal{
+				{Type: "Federated", Identifiers: []string{"cognito-identity.amazonaws.com"}},
+			},
+		},
+		"wildcard": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}]}`,
+			expected: []IAMPolicyStatementPrincipal{
+				{Type: "AWS", Identifiers: []string{"*"}},
+			},
+		},
+		"multiple_statements": {
+			json: `{"Version":"2012-10-17","Statement":[
+  {"Effect":"Allow","Principal":{"Service":["lambda.amazonaws.com","ec2.amazonaws.com"]},"Action":"sts:AssumeRole"},
+  {"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com","AWS":"*"},"Action":"sts:AssumeRole"},
+  {"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}
+]}`, // lintignore:AWSAT005
+			expected: []IAMPolicyStatementPrincipal{
+				{Type: "AWS", Identifiers: []string{"*"}},
+				{Type: "Service", Identifiers: []string{"ec2.amazonaws.com", "lambda.amazonaws.com"}},
+			},
+		},
+		"no_principal": {
+			json:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`,
+			expected: []IAMPolicyStatementPrincipal{},
+		},
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyAllowedPrincipals(testcase.json)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testcase.expected) {
+				t.Errorf("expected %v, got %v", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPolicyCanonicalHash(t *testing.T) {
+	t.Parallel()
+
+	const base = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole","sts:TagSession"],"Principal":{"AWS":"arn:aws:iam::111122223333:root","Service":["ec2.amazonaws.com","lambda.amazonaws.com"]},"Condition":{"StringEquals":{"sts:ExternalId":["a","b"]}}},{"Sid":"Deny","Effect":"Deny","Action":"sts:AssumeRole","Principal":"*"}]}` // lintignore:AWSAT005
+
+	testcases := map[string]struct {
+		json string
+		same bool
+	}{
+		"reformatted": {
+			json: `{
+  "Statement": [
+    {
+      "Principal": {
+        "Service": ["ec2.amazonaws.com", "lambda.amazonaws.com"],
+        "AWS": "arn:aws:iam::111122223333:root"
+      },
+      "Condition": {"StringEquals": {"sts:ExternalId": ["a", "b"]}},
+      "Action": ["sts:AssumeRole", "sts:TagSession"],
+      "Effect": "Allow"
+    },
+    {"Principal": "*", "Action": "sts:AssumeRole", "Effect": "Deny", "Sid": "Deny"}
+  ],
+  "Version": "2012-10-17"
+}`, // lintignore:AWSAT005
+			same: true,
+		},
+		"reordered": {
+			json: `{"Version":"2012-10-17","Statement":[{"Sid":"Deny","Effect":"deny","Action":["sts:AssumeRole"],"Principal":"*"},{"Effect":"Allow","Action":["sts:TagSession","sts:AssumeRole"],"Principal":{"Service":["lambda.amazonaws.com","ec2.amazonaws.com"],"AWS":["111122223333"]},"Condition":{"StringEquals":{"sts:ExternalId":["b","a"]}}}]}`,
+			same: true,
+		},
+		"action changed": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole"],"Principal":{"AWS":"arn:aws:iam::111122223333:root","Service":["ec2.amazonaws.com","lambda.amazonaws.com"]},"Condition":{"StringEquals":{"sts:ExternalId":["a","b"]}}},{"Sid":"Deny","Effect":"Deny","Action":"sts:AssumeRole","Principal":"*"}]}`, // lintignore:AWSAT005
+		},
+		"principal changed": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole","sts:TagSession"],"Principal":{"AWS":"arn:aws:iam::444455556666:root","Service":["ec2.amazonaws.com","lambda.amazonaws.com"]},"Condition":{"StringEquals":{"sts:ExternalId":["a","b"]}}},{"Sid":"Deny","Effect":"Deny","Action":"sts:AssumeRole","Principal":"*"}]}`, // lintignore:AWSAT005
+		},
+		"condition changed": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole","sts:TagSession"],"Principal":{"AWS":"arn:aws:iam::111122223333:root","Service":["ec2.amazonaws.com","lambda.amazonaws.com"]},"Condition":{"StringLike":{"sts:ExternalId":["a","b"]}}},{"Sid":"Deny","Effect":"Deny","Action":"sts:AssumeRole","Principal":"*"}]}`, // lintignore:AWSAT005
+		},
+		"sid changed": {
+			json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole","sts:TagSession"],"Principal":{"AWS":"arn:aws:iam::111122223333:root","Service":["ec2.amazonaws.com","lambda.amazonaws.com"]},"Condition":{"StringEquals":{"sts:ExternalId":["a","b"]}}},{"Sid":"DenyAll","Effect":"Deny","Action":"sts:AssumeRole","Principal":"*"}]}`, // lintignore:AWSAT005
+		},
+	}
+
+	want, err := policyCanonicalHash(base)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, testcase := range testcases {
+		testcase := testcase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyCanonicalHash(testcase.json)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if same := got == want; same != testcase.same {
+				t.Errorf("hash equal: %t, want %t", same, testcase.same)
+			}
+
+			// The hash changes exactly when the diff suppression reports a change.
+			equivalent, err := awspolicy.PoliciesAreEquivalent(base, testcase.json)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if equivalent != testcase.same {
+				t.Errorf("policies equivalent: %t, want %t", equivalent, testcase.same)
+			}
+		})
+	}
+}
+
+func TestPolicyCanonicalHash_singleStatement(t *testing.T) {
+	t.Parallel()
+
+	single, err := policyCanonicalHash(`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	list, err := policyCanonicalHash(`[{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sts:AssumeRole"],"Principal":{"Service":["ec2.amazonaws.com"]}}]}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if single != list {
+		t.Errorf("got different hashes %s and %s", single, list)
+	}
+
+	if _, err := policyCanonicalHash(`{"Statement":`); err == nil {
+		t.Error("expected error for invalid JSON, got none")
+	}
+}
@@ -5,18 +5,25 @@ package iam
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/url"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	awspolicy "github.com/hashicorp/awspolicyequivalence"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,35 +38,87 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
 	"github.com/hashicorp/terraform-provider-aws/names"
+	"golang.org/x/exp/slices"
 )
 
 const (
 	roleNameMaxLen       = 64
 	roleNamePrefixMaxLen = roleNameMaxLen - id.UniqueIDSuffixLength
+
+	roleAutoTagNameKey = "Name"
+
+	roleDescriptionMaxLen = 1000
+
+	// roleServiceLinkedPathPrefix is the path of service-linked roles, which are created by AWS services.
+	roleServiceLinkedPathPrefix = "/aws-service-role/"
+
+	roleDescriptionOnOverflowError    = "error"
+	roleDescriptionOnOverflowTruncate = "truncate"
+
+	roleMaxSessionDurationMin = 3600
+	roleMaxSessionDurationMax = 43200
+
+	// roleChainingMaxSessionDuration is the longest session, in seconds, that STS allows when a role is assumed by another role.
+	roleChainingMaxSessionDuration = 3600
+
+	// roleEntityAlreadyExistsTimeout bounds how long role creation is retried while IAM reports that the role already exists.
+	roleEntityAlreadyExistsTimeout = 30 * time.Second
+
+	// roleInlinePolicyNoSuchEntityTimeout bounds how long adding an inline policy is retried while IAM reports that the role does not exist.
+	roleInlinePolicyNoSuchEntityTimeout = 10 * time.Second
+
+	// roleInlinePolicyReadConcurrency bounds the number of concurrent GetRolePolicy calls made when reading inline policies.
+	roleInlinePolicyReadConcurrency = 5
+
+	// roleInlinePolicyThrottlingTimeout bounds how long reading an inline policy is retried, with backoff, while IAM throttles requests.
+	roleInlinePolicyThrottlingTimeout = 1 * time.Minute
+
+	// roleManagedPolicyConcurrency bounds the number of concurrent managed policy attachment and detachment calls.
+	roleManagedPolicyConcurrency = 5
+
+	// roleReadThrottlingTimeout bounds how long reading the role is retried, with backoff, while IAM throttles requests.
+	roleReadThrottlingTimeout = 1 * time.Minute
 )
 
 // @SDKResource("aws_iam_role", name="Role")
 // @Tags
 func ResourceRole() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceRoleCreate,
+		CreateWithoutTimeout: roleAudited(roleAuditOperationCreate, resourceRoleCreate),
 		ReadWithoutTimeout:   resourceRoleRead,
-		UpdateWithoutTimeout: resourceRoleUpdate,
-		DeleteWithoutTimeout: resourceRoleDelete,
+		UpdateWithoutTimeout: roleAudited(roleAuditOperationUpdate, resourceRoleUpdate),
+		DeleteWithoutTimeout: roleAudited(roleAuditOperationDelete, resourceRoleDelete),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceRoleImport,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"adopt_external_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"adopted_tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"arn": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"assume_role_policy": {
 				Type:                  schema.TypeString,
-				Required:              true,
-				ValidateFunc:          validation.StringIsJSON,
+				Optional:              true,
+				Computed:              true,
+				ExactlyOneOf:          []string{"assume_role_policy", "assume_role_policy_document", "trusted_services"},
+				ValidateFunc:          validRoleAssumeRolePolicy,
 				DiffSuppressFunc:      verify.SuppressEquivalentPolicyDiffs,
 				DiffSuppressOnRefresh: true,
 				StateFunc: func(v interface{}) string {
@@ -67,24 +126,209 @@ func ResourceRole() *schema.Resource {
 					return json
 				},
 			},
-			"create_date": {
+			"assume_role_principals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifiers": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"assume_role_policy_document": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"assume_role_policy", "assume_role_policy_document", "trusted_services"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"session_tag_keys": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringLenBetween(1, 128),
+							},
+						},
+						"statement": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"actions": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"condition": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"test": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"values": {
+													Type:     schema.TypeList,
+													Required: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+												},
+												"variable": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+									"effect": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "Allow",
+										ValidateFunc: validation.StringInSlice([]string{"Allow", "Deny"}, false),
+									},
+									"principals": dataSourcePolicyPrincipalSchema(),
+									"sid": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "2012-10-17",
+							ValidateFunc: validation.StringInSlice([]string{
+								"2008-10-17",
+								"2012-10-17",
+							}, false),
+						},
+					},
+				},
+			},
+			"assume_role_policy_hash": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"description": {
+			"assume_role_policy_ignore_statements": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"assume_role_policy_wait_for_propagation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"auto_tag_name": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"compute_boundary_analysis": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"compute_boundary_document": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"create_date": {
 				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"create_instance_profile": {
+				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
+			},
+			"create_date_unix": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"create_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: verify.ValidDuration,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressTruncatedRoleDescriptionDiff,
 				ValidateFunc: validation.All(
-					validation.StringLenBetween(0, 1000),
+					validation.StringLenBetween(0, roleDescriptionMaxLen),
 					validation.StringDoesNotMatch(regexp.MustCompile("[“‘]"), "cannot contain specially formatted single or double quotes: [“‘]"),
 					validation.StringMatch(regexp.MustCompile(`[\p{L}\p{M}\p{Z}\p{S}\p{N}\p{P}]*`), `must satisfy regular expression pattern: [\p{L}\p{M}\p{Z}\p{S}\p{N}\p{P}]*)`),
 				),
 			},
+			"description_on_overflow": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      roleDescriptionOnOverflowError,
+				ValidateFunc: validation.StringInSlice([]string{roleDescriptionOnOverflowError, roleDescriptionOnOverflowTruncate}, false),
+			},
+			"destroy_managed_inline_policies_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"effective_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fail_on_existing_inline": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"force_detach_policies": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"forbid_external_inline_policy_deletion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"forbid_iam_full_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"has_permissions_boundary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ignore_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ignore_tag_prefixes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"inline_policy": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -102,7 +346,7 @@ func ResourceRole() *schema.Resource {
 						"policy": {
 							Type:                  schema.TypeString,
 							Optional:              true, // semantically required but syntactically optional to allow empty inline_policy
-							ValidateFunc:          verify.ValidIAMPolicyJSON,
+							ValidateFunc:          validRoleInlinePolicyDocument,
 							DiffSuppressFunc:      verify.SuppressEquivalentPolicyDiffs,
 							DiffSuppressOnRefresh: true,
 							StateFunc: func(v interface{}) string {
@@ -120,6 +364,42 @@ func ResourceRole() *schema.Resource {
 					return !inlinePoliciesActualDiff(d)
 				},
 			},
+			"inline_policies": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				ConflictsWith:    []string{"inline_policy"},
+				ValidateFunc:     validRoleInlinePolicies,
+				DiffSuppressFunc: verify.SuppressEquivalentPolicyDiffs,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+			},
+			"inline_policy_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"inline_policy_atomic": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"inline_policy_exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"instance_profile_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_profile_names": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"managed_policies_exceeding_boundary": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"managed_policy_arns": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -129,11 +409,43 @@ func ResourceRole() *schema.Resource {
 					ValidateFunc: verify.ValidARN,
 				},
 			},
+			"managed_policy_arns_exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"managed_policy_arns_import_pending": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"managed_policy_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"managed_policy_names": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"managed_policy_arns"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validResourceName(policyNameMaxLen),
+				},
+			},
+			"managed_policy_names_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				ValidateFunc: validRolePath,
+			},
 			"max_session_duration": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				Default:      3600,
-				ValidateFunc: validation.IntBetween(3600, 43200),
+				Default:      roleMaxSessionDurationMin,
+				ValidateFunc: validRoleMaxSessionDuration,
+			},
+			"max_session_duration_is_maximum": {
+				Type:     schema.TypeBool,
+				Computed: true,
 			},
 			"name": {
 				Type:          schema.TypeString,
@@ -141,7 +453,7 @@ func ResourceRole() *schema.Resource {
 				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"name_prefix"},
-				ValidateFunc:  validResourceName(roleNameMaxLen),
+				ValidateFunc:  validation.All(validRoleName(roleNameMaxLen), validRoleNameNotReserved),
 			},
 			"name_prefix": {
 				Type:          schema.TypeString,
@@ -149,744 +461,2849 @@ func ResourceRole() *schema.Resource {
 				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"name"},
-				ValidateFunc:  validResourceName(roleNamePrefixMaxLen),
+				ValidateFunc:  validation.All(validRoleName(roleNamePrefixMaxLen), validRoleNameNotReserved),
+			},
+			"normalize_inline_policies": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"partial_read_on_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"path": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "/",
 				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 512),
+				ValidateFunc: validRolePathNormalized,
+				StateFunc: func(v interface{}) string {
+					return normalizeRolePath(v.(string))
+				},
 			},
 			"permissions_boundary": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: verify.ValidARN,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validPolicyARN,
+				DiffSuppressFunc: suppressEquivalentPolicyARN,
+			},
+			"permissions_boundary_document": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"permissions_boundary_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role_last_used": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"last_used_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"service_linked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"skip_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			names.AttrTags:    tftags.TagsSchema(),
 			names.AttrTagsAll: tftags.TagsSchemaComputed(),
+			"trim_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"trusted_services": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"assume_role_policy", "assume_role_policy_document", "trusted_services"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"trusts_org_root": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"unique_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"validate_permissions_boundary": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			verify.SetTagsDiff,
+			customizeDiffRoleManagedPolicyNames,
+			customizeDiffRoleFullIAMAccess,
+			customizeDiffRoleInlinePolicyNames,
+			customizeDiffRoleManagedPolicyARNs,
+			customizeDiffRolePermissionsBoundary,
+			customizeDiffRoleAssumeRolePolicy,
+			customizeDiffRoleEffectiveName,
+		),
 	}
 }
 
-func resourceRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	d.Set("force_detach_policies", false)
-	return []*schema.ResourceData{d}, nil
-}
+// customizeDiffRoleFullIAMAccess fails the plan if `forbid_iam_full_access` is set and
+// any inline or attached managed policy allows `iam:*` on all resources.
+func customizeDiffRoleFullIAMAccess(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("forbid_iam_full_access").(bool) {
+		return nil
+	}
 
-func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+	for _, tfMapRaw := range d.Get("inline_policy").(*schema.Set).List() {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
 
-	assumeRolePolicy, err := structure.NormalizeJsonString(d.Get("assume_role_policy").(string))
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "assume_role_policy (%s) is invalid JSON: %s", assumeRolePolicy, err)
-	}
+		policy, ok := tfMap["policy"].(string)
+		if !ok || policy == "" {
+			continue
+		}
 
-	name := create.Name(d.Get("name").(string), d.Get("name_prefix").(string))
-	input := &iam.CreateRoleInput{
-		AssumeRolePolicyDocument: aws.String(assumeRolePolicy),
-		Path:                     aws.String(d.Get("path").(string)),
-		RoleName:                 aws.String(name),
-		Tags:                     getTagsIn(ctx),
+		if fullAccess, err := policyAllowsFullIAMAccess(policy); err != nil {
+			return fmt.Errorf("inline_policy (%s): %w", tfMap["name"], err)
+		} else if fullAccess {
+			return fmt.Errorf("inline_policy (%s) allows wildcard IAM actions on all resources and forbid_iam_full_access is set", tfMap["name"])
+		}
 	}
 
-	if v, ok := d.GetOk("description"); ok {
-		input.Description = aws.String(v.(string))
-	}
+	for name, v := range d.Get("inline_policies").(map[string]interface{}) {
+		policy, ok := v.(string)
+		if !ok || policy == "" {
+			continue
+		}
 
-	if v, ok := d.GetOk("max_session_duration"); ok {
-		input.MaxSessionDuration = aws.Int64(int64(v.(int)))
+		if fullAccess, err := policyAllowsFullIAMAccess(policy); err != nil {
+			return fmt.Errorf("inline_policies (%s): %w", name, err)
+		} else if fullAccess {
+			return fmt.Errorf("inline_policies (%s) allows wildcard IAM actions on all resources and forbid_iam_full_access is set", name)
+		}
 	}
 
-	if v, ok := d.GetOk("permissions_boundary"); ok {
-		input.PermissionsBoundary = aws.String(v.(string))
+	if !d.NewValueKnown("managed_policy_arns") {
+		return nil
 	}
 
-	output, err := retryCreateRole(ctx, conn, input)
-
-	// Some partitions (e.g. ISO) may not support tag-on-create.
-	if input.Tags != nil && errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
-		input.Tags = nil
-
-		output, err = retryCreateRole(ctx, conn, input)
-	}
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
 
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "creating IAM Role (%s): %s", name, err)
+	var policyARNs []string
+	for _, policyARN := range flex.ExpandStringValueSet(d.Get("managed_policy_arns").(*schema.Set)) {
+		if policyARN != "" {
+			policyARNs = append(policyARNs, policyARN)
+		}
 	}
 
-	roleName := aws.StringValue(output.Role.RoleName)
+	return forEachRoleManagedPolicy(policyARNs, func(policyARN string) error {
+		policy, err := findPolicyDocumentByARN(ctx, conn, policyARN)
 
-	if v, ok := d.GetOk("inline_policy"); ok && v.(*schema.Set).Len() > 0 {
-		policies := expandRoleInlinePolicies(roleName, v.(*schema.Set).List())
-		if err := addRoleInlinePolicies(ctx, policies, meta); err != nil {
-			return sdkdiag.AppendErrorf(diags, "creating IAM Role (%s): %s", name, err)
+		if err != nil {
+			return fmt.Errorf("reading managed policy (%s): %w", policyARN, err)
 		}
-	}
 
-	if v, ok := d.GetOk("managed_policy_arns"); ok && v.(*schema.Set).Len() > 0 {
-		managedPolicies := flex.ExpandStringSet(v.(*schema.Set))
-		if err := addRoleManagedPolicies(ctx, roleName, managedPolicies, meta); err != nil {
-			return sdkdiag.AppendErrorf(diags, "creating IAM Role (%s): %s", name, err)
+		if fullAccess, err := policyAllowsFullIAMAccess(policy); err != nil {
+			return fmt.Errorf("managed policy (%s): %w", policyARN, err)
+		} else if fullAccess {
+			return fmt.Errorf("managed policy (%s) allows wildcard IAM actions on all resources and forbid_iam_full_access is set", policyARN)
 		}
-	}
 
-	d.SetId(roleName)
+		return nil
+	})
+}
 
-	// For partitions not supporting tag-on-create, attempt tag after create.
-	if tags := getTagsIn(ctx); input.Tags == nil && len(tags) > 0 {
-		err := roleCreateTags(ctx, conn, d.Id(), tags)
+// customizeDiffRoleInlinePolicyNames fails the plan if two `inline_policy` blocks have the same name,
+// which IAM would otherwise only reject (or silently overwrite) at apply time.
+func customizeDiffRoleInlinePolicyNames(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	names := make(map[string]struct{})
 
-		// If default tags only, continue. Otherwise, error.
-		if v, ok := d.GetOk(names.AttrTags); (!ok || len(v.(map[string]interface{})) == 0) && errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
-			return append(diags, resourceRoleRead(ctx, d, meta)...)
+	for _, tfMapRaw := range d.Get("inline_policy").(*schema.Set).List() {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// Unknown names and the empty block used to remove all inline policies have no name.
+		name, ok := tfMap["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("inline_policy names must be unique, %q is used more than once", name)
+		}
+
+		names[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// customizeDiffRoleAssumeRolePolicy plans `assume_role_policy` from a configured
+// `assume_role_policy_document` block or `trusted_services` set. As the planned value is compared with the trust policy
+// read from IAM, out-of-band changes to the trust policy show as a diff.
+func customizeDiffRoleAssumeRolePolicy(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("assume_role_policy_document") || !d.NewValueKnown("trusted_services") {
+		return d.SetNewComputed("assume_role_policy")
+	}
+
+	var policy string
+	var err error
+
+	if v, ok := d.GetOk("assume_role_policy_document"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		policy, err = expandRoleAssumeRolePolicyDocument(v.([]interface{})[0].(map[string]interface{}))
+	} else if v, ok := d.GetOk("trusted_services"); ok && v.(*schema.Set).Len() > 0 {
+		policy, err = expandRoleTrustedServicesPolicy(flex.ExpandStringValueSet(v.(*schema.Set)))
+	} else {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if current := d.Get("assume_role_policy").(string); current != "" {
+		if equivalent, err := awspolicy.PoliciesAreEquivalent(current, policy); err == nil && equivalent {
+			return nil
+		}
+	}
+
+	return d.SetNew("assume_role_policy", policy)
+}
+
+// customizeDiffRoleEffectiveName plans `effective_name` from a configured `name`, so that it is known before the
+// role is created. A name generated from `name_prefix`, or by Terraform, is only known after apply.
+func customizeDiffRoleEffectiveName(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("name", "name_prefix") {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return d.SetNewComputed("effective_name")
+	}
+
+	if v := rawConfig.GetAttr("name"); v.IsKnown() && !v.IsNull() {
+		return d.SetNew("effective_name", v.AsString())
+	}
+
+	return d.SetNewComputed("effective_name")
+}
+
+// customizeDiffRoleManagedPolicyNames plans `managed_policy_arns` from the configured `managed_policy_names`,
+// which are customer managed policies in the provider's account, so that Create and Update attach them by ARN.
+func customizeDiffRoleManagedPolicyNames(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || rawConfig.GetAttr("managed_policy_names").IsNull() {
+		return nil
+	}
+
+	if !d.NewValueKnown("managed_policy_names") || !d.NewValueKnown("managed_policy_names_path") {
+		return d.SetNewComputed("managed_policy_arns")
+	}
+
+	client := meta.(*conns.AWSClient)
+	policyNames := flex.ExpandStringValueSet(d.Get("managed_policy_names").(*schema.Set))
+
+	return d.SetNew("managed_policy_arns", expandRoleManagedPolicyNames(client.Partition, client.AccountID, d.Get("managed_policy_names_path").(string), policyNames))
+}
+
+// expandRoleManagedPolicyNames returns the ARNs of the customer managed policies with the specified names and path.
+func expandRoleManagedPolicyNames(partition, accountID, path string, policyNames []string) []string {
+	policyARNs := make([]string, 0, len(policyNames))
+
+	for _, policyName := range policyNames {
+		policyARNs = append(policyARNs, arn.ARN{
+			Partition: partition,
+			Service:   iam.ServiceName,
+			AccountID: accountID,
+			Resource:  "policy" + path + policyName,
+		}.String())
+	}
+
+	return policyARNs
+}
+
+// customizeDiffRoleManagedPolicyARNs fails the plan if two `managed_policy_arns` entries,
+// although written differently, identify the same managed policy.
+func customizeDiffRoleManagedPolicyARNs(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("managed_policy_arns") {
+		return nil
+	}
+
+	policyARNs := make(map[string]string)
+
+	for _, policyARN := range flex.ExpandStringValueSet(d.Get("managed_policy_arns").(*schema.Set)) {
+		key := policyARNKey(policyARN)
+
+		if other, ok := policyARNs[key]; ok {
+			return fmt.Errorf("managed_policy_arns contains duplicate entries for the same policy: %q and %q", other, policyARN)
+		}
+
+		policyARNs[key] = policyARN
+	}
+
+	return nil
+}
+
+// customizeDiffRolePermissionsBoundary fails the plan if `validate_permissions_boundary` is set and
+// a new or changed `permissions_boundary` does not exist, which CreateRole would otherwise only reject at apply time.
+func customizeDiffRolePermissionsBoundary(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_permissions_boundary").(bool) {
+		return nil
+	}
+
+	if d.Id() != "" && !d.HasChange("permissions_boundary") {
+		return nil
+	}
+
+	if !d.NewValueKnown("permissions_boundary") {
+		return nil
+	}
+
+	boundaryARN := d.Get("permissions_boundary").(string)
+	if boundaryARN == "" {
+		return nil
+	}
+
+	return checkRolePermissionsBoundaryExists(ctx, meta.(*conns.AWSClient).IAMConn(ctx), boundaryARN)
+}
+
+// checkRolePermissionsBoundaryExists returns an error if the permissions boundary policy does not exist.
+func checkRolePermissionsBoundaryExists(ctx context.Context, conn *iam.IAM, boundaryARN string) error {
+	_, err := FindPolicyByARN(ctx, conn, boundaryARN)
+
+	if tfresource.NotFound(err) {
+		return fmt.Errorf("permissions_boundary (%s) does not exist; create the policy before the role or correct the ARN", boundaryARN)
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading permissions_boundary (%s): %w", boundaryARN, err)
+	}
+
+	return nil
+}
+
+func resourceRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	if arn.IsARN(d.Id()) {
+		rolePath, roleName, err := parseRoleARN(d.Id())
+		if err != nil {
+			return nil, err
 		}
 
+		// Role names are unique within an account regardless of path, so the role found by name
+		// may have been recreated at a different path than the one in the imported ARN.
+		role, err := FindRoleByName(ctx, conn, roleName)
 		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "setting IAM Role (%s) tags: %s", d.Id(), err)
+			return nil, fmt.Errorf("reading IAM Role (%s): %w", roleName, err)
+		}
+
+		if v := aws.StringValue(role.Path); v != rolePath {
+			return nil, fmt.Errorf("IAM Role (%s) has path %s, not the path %s in the imported ARN (%s); role names are unique within an account, so import the role by name or by its current ARN (%s)", roleName, v, rolePath, d.Id(), aws.StringValue(role.Arn))
 		}
+
+		d.SetId(roleName)
 	}
 
-	return append(diags, resourceRoleRead(ctx, d, meta)...)
+	// managed_policy_arns is read from IAM, so the configuration may not yet list every attached policy.
+	// The first update after import does not detach any, see roleImportedManagedPolicyDetachments.
+	managedPolicies, err := readRolePolicyAttachments(ctx, conn, d.Id())
+	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, fmt.Errorf("reading IAM Role (%s) managed policies: %w", d.Id(), err)
+	}
+	if len(managedPolicies) > 0 {
+		d.Set("managed_policy_arns_import_pending", true)
+	}
+
+	d.Set("adopt_existing", false)
+	d.Set("adopt_external_tags", false)
+	d.Set("assume_role_policy_wait_for_propagation", false)
+	d.Set("auto_tag_name", false)
+	d.Set("compute_boundary_analysis", false)
+	d.Set("compute_boundary_document", false)
+	d.Set("create_instance_profile", false)
+	d.Set("description_on_overflow", roleDescriptionOnOverflowError)
+	d.Set("destroy_managed_inline_policies_only", false)
+	d.Set("fail_on_existing_inline", false)
+	d.Set("forbid_external_inline_policy_deletion", false)
+	d.Set("forbid_iam_full_access", false)
+	d.Set("inline_policy_atomic", false)
+	d.Set("inline_policy_exclusive", true)
+	d.Set("managed_policy_arns_exclusive", true)
+	d.Set("managed_policy_names_path", "/")
+	d.Set("normalize_inline_policies", false)
+	d.Set("partial_read_on_error", false)
+	d.Set("skip_destroy", false)
+	d.Set("trim_tags", false)
+	d.Set("validate_permissions_boundary", false)
+	// force_detach_policies is not stored in IAM, so an imported role starts with the default.
+	// Read never resets it; once `force_detach_policies = true` is applied the value is kept across refreshes.
+	d.Set("force_detach_policies", false)
+	return []*schema.ResourceData{d}, nil
 }
 
-func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// rolePermissionsBoundaryName returns the name of the permissions boundary policy with the specified ARN,
+// the last segment of a pathed ARN. An empty string is returned for an invalid ARN.
+func rolePermissionsBoundaryName(boundaryARN string) string {
+	parsedARN, err := arn.Parse(boundaryARN)
+	if err != nil {
+		return ""
+	}
+
+	name, _ := policyNameFromARNResource(parsedARN.Resource)
+
+	return name
+}
+
+// roleNameFromARN returns the name of the role with the specified ARN, ignoring any path.
+func roleNameFromARN(v string) (string, error) {
+	_, roleName, err := parseRoleARN(v)
+
+	return roleName, err
+}
+
+// parseRoleARN returns the path and name of the role with the specified ARN.
+func parseRoleARN(v string) (string, string, error) {
+	parsedARN, err := arn.Parse(v)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing IAM Role ARN (%s): %w", v, err)
+	}
+
+	const resourcePrefix = "role/"
+	if parsedARN.Service != iam.ServiceName || !strings.HasPrefix(parsedARN.Resource, resourcePrefix) {
+		return "", "", fmt.Errorf("unexpected format for IAM Role ARN (%s), expected arn:PARTITION:iam::ACCOUNT:role/[PATH/]NAME", v)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(parsedARN.Resource, resourcePrefix), "/")
+	roleName := parts[len(parts)-1]
+
+	if roleName == "" {
+		return "", "", fmt.Errorf("unexpected format for IAM Role ARN (%s), expected arn:PARTITION:iam::ACCOUNT:role/[PATH/]NAME", v)
+	}
+
+	rolePath := "/"
+	if len(parts) > 1 {
+		rolePath = "/" + strings.Join(parts[:len(parts)-1], "/") + "/"
+	}
+
+	return rolePath, roleName, nil
+}
+
+var urlEncodedRegexp = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+
+// decodeRoleAssumeRolePolicy returns the JSON trust policy from a role's AssumeRolePolicyDocument.
+// IAM returns the document URL-encoded, but a document that is already JSON, for example one containing
+// a literal "%" in a condition value, is returned unchanged so that it is not decoded twice.
+func decodeRoleAssumeRolePolicy(v string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(v), "{") || !urlEncodedRegexp.MatchString(v) {
+		return v, nil
+	}
+
+	return url.QueryUnescape(v)
+}
+
+// roleAssumeRolePolicyError returns err with the problems found in the trust policy if err is a
+// MalformedPolicyDocument error. Other errors, and errors for which no problem is found, are returned unchanged.
+func roleAssumeRolePolicyError(policy string, err error) error {
+	if !tfawserr.ErrCodeEquals(err, iam.ErrCodeMalformedPolicyDocumentException) {
+		return err
+	}
+
+	problems := roleAssumeRolePolicyProblems(policy)
+	if len(problems) == 0 {
+		return err
+	}
+
+	return &MalformedPolicyDocumentError{
+		Problems: problems,
+		Err:      err,
+	}
+}
+
+// roleAssumeRolePolicyProblems returns the common mistakes in a trust policy that IAM rejects as malformed,
+// each prefixed with the statement it was found in.
+func roleAssumeRolePolicyProblems(policy string) []string {
+	var doc map[string]interface{}
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return []string{fmt.Sprintf("not valid JSON: %s", err)}
+	}
+
+	var problems []string
+
+	if v, ok := doc["Version"]; ok && v != "2012-10-17" && v != "2008-10-17" {
+		problems = append(problems, fmt.Sprintf("Version %v is not 2012-10-17 or 2008-10-17", v))
+	}
+
+	var statements []interface{}
+	switch v := doc["Statement"].(type) {
+	case []interface{}:
+		statements = v
+	case map[string]interface{}:
+		statements = []interface{}{v}
+	}
+
+	if len(statements) == 0 {
+		return append(problems, "Statement is missing or empty")
+	}
+
+	for i, v := range statements {
+		prefix := fmt.Sprintf("Statement[%d]", i)
+
+		statement, ok := v.(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not an object", prefix))
+			continue
+		}
+
+		if sid, ok := statement["Sid"].(string); ok && sid != "" {
+			prefix = fmt.Sprintf("%s (Sid %q)", prefix, sid)
+		}
+
+		if effect := statement["Effect"]; effect != "Allow" && effect != "Deny" {
+			if effect == nil {
+				problems = append(problems, fmt.Sprintf("%s: Effect is missing", prefix))
+			} else {
+				problems = append(problems, fmt.Sprintf("%s: Effect %q is not Allow or Deny", prefix, fmt.Sprint(effect)))
+			}
+		}
+
+		_, hasAction := statement["Action"]
+		_, hasNotAction := statement["NotAction"]
+		switch {
+		case hasAction && hasNotAction:
+			problems = append(problems, fmt.Sprintf("%s: has both Action and NotAction", prefix))
+		case !hasAction && !hasNotAction:
+			problems = append(problems, fmt.Sprintf("%s: Action is missing", prefix))
+		}
+
+		for _, key := range []string{"Action", "NotAction"} {
+			v, ok := statement[key]
+			if !ok {
+				continue
+			}
+
+			actions := policyStatementValues(v)
+			if len(actions) == 0 {
+				problems = append(problems, fmt.Sprintf("%s: %s is empty", prefix, key))
+			}
+
+			for _, action := range actions {
+				if action == "" {
+					problems = append(problems, fmt.Sprintf("%s: %s contains an empty value", prefix, key))
+				} else if action != "*" && !strings.Contains(action, ":") {
+					problems = append(problems, fmt.Sprintf("%s: %s %q is not of the form service:action", prefix, key, action))
+				}
+			}
+		}
+
+		_, hasPrincipal := statement["Principal"]
+		_, hasNotPrincipal := statement["NotPrincipal"]
+		switch {
+		case hasPrincipal && hasNotPrincipal:
+			problems = append(problems, fmt.Sprintf("%s: has both Principal and NotPrincipal", prefix))
+		case !hasPrincipal && !hasNotPrincipal:
+			problems = append(problems, fmt.Sprintf("%s: Principal is missing, a trust policy must specify who can assume the role", prefix))
+		}
+
+		for _, key := range []string{"Principal", "NotPrincipal"} {
+			switch v := statement[key].(type) {
+			case string:
+				if v != "*" {
+					problems = append(problems, fmt.Sprintf("%s: %s %q is not \"*\" or an object", prefix, key, v))
+				}
+			case map[string]interface{}:
+				if len(v) == 0 {
+					problems = append(problems, fmt.Sprintf("%s: %s is empty", prefix, key))
+				}
+
+				types := make([]string, 0, len(v))
+				for typ := range v {
+					types = append(types, typ)
+				}
+				slices.Sort(types)
+
+				for _, typ := range types {
+					if len(policyStatementValues(v[typ])) == 0 {
+						problems = append(problems, fmt.Sprintf("%s: %s %s has no identifiers", prefix, key, typ))
+					}
+				}
+			}
+		}
+
+		for _, key := range []string{"Resource", "NotResource"} {
+			if _, ok := statement[key]; ok {
+				problems = append(problems, fmt.Sprintf("%s: %s is not allowed in a trust policy", prefix, key))
+			}
+		}
+	}
+
+	return problems
+}
+
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).IAMConn(ctx)
 
-	outputRaw, err := tfresource.RetryWhenNewResourceNotFound(ctx, propagationTimeout, func() (interface{}, error) {
-		return FindRoleByName(ctx, conn, d.Id())
-	}, d.IsNewResource())
-
-	if !d.IsNewResource() && tfresource.NotFound(err) {
-		log.Printf("[WARN] IAM Role (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return diags
+	path := normalizeRolePath(d.Get("path").(string))
+	if roleIsServiceLinked(path) {
+		return sdkdiag.AppendErrorf(diags, "creating IAM Role: path (%s) is reserved for service-linked roles, which are created by AWS services; use the aws_iam_service_linked_role resource instead", path)
 	}
 
+	assumeRolePolicy, err := expandRoleAssumeRolePolicy(d)
 	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", d.Id(), err)
+		return sdkdiag.AppendErrorf(diags, "creating IAM Role: %s", err)
 	}
 
-	role := outputRaw.(*iam.Role)
+	tags := getTagsIn(ctx)
+	if d.Get("trim_tags").(bool) {
+		tags = trimRoleTags(tags)
+	}
 
-	// occasionally, immediately after a role is created, AWS will give an ARN like AROAQ7SSZBKHREXAMPLE (unique ID)
-	if role, err = waitRoleARNIsNotUniqueID(ctx, conn, d.Id(), role); err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): waiting for valid ARN: %s", d.Id(), err)
+	name := create.Name(d.Get("name").(string), d.Get("name_prefix").(string))
+	if d.Get("auto_tag_name").(bool) {
+		tags = append(tags, Tags(roleAutoTags(roleIgnoreTagsConfig(ctx, d, meta.(*conns.AWSClient).IgnoreTagsConfig), name, KeyValueTags(ctx, tags)))...)
+	}
+	input := &iam.CreateRoleInput{
+		AssumeRolePolicyDocument: aws.String(assumeRolePolicy),
+		Path:                     aws.String(path),
+		RoleName:                 aws.String(name),
+		Tags:                     tags,
 	}
 
-	d.Set("arn", role.Arn)
-	d.Set("create_date", role.CreateDate.Format(time.RFC3339))
-	d.Set("description", role.Description)
-	d.Set("max_session_duration", role.MaxSessionDuration)
-	d.Set("name", role.RoleName)
-	d.Set("name_prefix", create.NamePrefixFromName(aws.StringValue(role.RoleName)))
-	d.Set("path", role.Path)
-	if role.PermissionsBoundary != nil {
-		d.Set("permissions_boundary", role.PermissionsBoundary.PermissionsBoundaryArn)
+	if v, ok := d.GetOk("description"); ok {
+		description, err := expandRoleDescription(v.(string), d.Get("description_on_overflow").(string))
+		if err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+
+		input.Description = aws.String(description)
+	}
+
+	if v, ok := d.GetOk("max_session_duration"); ok {
+		input.MaxSessionDuration = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("permissions_boundary"); ok {
+		input.PermissionsBoundary = aws.String(v.(string))
+	}
+
+	timeout := rolePropagationTimeout(meta)
+	if v, ok := d.GetOk("create_timeout"); ok {
+		if v, _ := time.ParseDuration(v.(string)); v > 0 {
+			timeout = v
+		}
+	}
+
+	output, err := retryCreateRole(ctx, conn, input, timeout)
+
+	// Some partitions (e.g. ISO) may not support tag-on-create.
+	if input.Tags != nil && errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
+		input.Tags = nil
+
+		output, err = retryCreateRole(ctx, conn, input, timeout)
+	}
+
+	adopted := false
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeEntityAlreadyExistsException) && d.Get("adopt_existing").(bool) {
+		role, adoptErr := adoptRole(ctx, conn, input)
+
+		if adoptErr != nil {
+			return sdkdiag.AppendErrorf(diags, "creating IAM Role (%s): %s: %s", name, err, adoptErr)
+		}
+
+		log.Printf("[WARN] Adopting existing IAM Role (%s)", name)
+		output = &iam.CreateRoleOutput{Role: role}
+		err = nil
+		adopted = true
 	}
-	d.Set("unique_id", role.RoleId)
 
-	assumeRolePolicy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
 	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", d.Id(), err)
+		return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, roleAssumeRolePolicyError(aws.StringValue(input.AssumeRolePolicyDocument), err)))
+	}
+
+	roleName := aws.StringValue(output.Role.RoleName)
+
+	// Record the role before attaching policies so that a failed attachment leaves a tainted role in state
+	// rather than an untracked one.
+	d.SetId(roleName)
+
+	// Only an adopted role can already have inline policies.
+	var existingInlinePolicyNames []string
+	if adopted {
+		policyNames, err := readRolePolicyNames(ctx, conn, roleName)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", roleName, err)
+		}
+
+		existingInlinePolicyNames = aws.StringValueSlice(policyNames)
+	}
+
+	if v, ok := d.GetOk("inline_policy"); ok && v.(*schema.Set).Len() > 0 {
+		policies := expandRoleInlinePolicies(roleName, v.(*schema.Set).List())
+		if d.Get("normalize_inline_policies").(bool) {
+			if err := normalizeRoleInlinePolicies(policies); err != nil {
+				return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+			}
+		}
+		if err := checkRoleExistingInlinePolicies(roleName, existingInlinePolicyNames, policies, d.Get("fail_on_existing_inline").(bool)); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+		if err := putRoleInlinePolicies(ctx, conn, policies, d.Get("inline_policy_atomic").(bool), rolePropagationTimeout(meta)); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+	}
+
+	if v, ok := d.GetOk("inline_policies"); ok && len(v.(map[string]interface{})) > 0 {
+		_, policies := expandRoleInlinePoliciesMapChanges(roleName, nil, v.(map[string]interface{}))
+		if d.Get("normalize_inline_policies").(bool) {
+			if err := normalizeRoleInlinePolicies(policies); err != nil {
+				return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+			}
+		}
+		if err := checkRoleExistingInlinePolicies(roleName, existingInlinePolicyNames, policies, d.Get("fail_on_existing_inline").(bool)); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+		if err := putRoleInlinePolicies(ctx, conn, policies, d.Get("inline_policy_atomic").(bool), rolePropagationTimeout(meta)); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+	}
+
+	if v, ok := d.GetOk("managed_policy_arns"); ok && v.(*schema.Set).Len() > 0 {
+		managedPolicies := flex.ExpandStringSet(v.(*schema.Set))
+		if err := addRoleManagedPolicies(ctx, roleName, managedPolicies, meta); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+
+		diags = appendRoleBoundaryCappedPolicyWarnings(diags, roleName, d.Get("permissions_boundary").(string), aws.StringValueSlice(managedPolicies))
+	}
+
+	if d.Get("create_instance_profile").(bool) {
+		if err := createRoleInstanceProfile(ctx, conn, roleName, path, rolePropagationTimeout(meta)); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationCreate, name, err))
+		}
+	}
+
+	// For partitions not supporting tag-on-create, and for an adopted role, attempt tag after create.
+	if (input.Tags == nil || adopted) && len(tags) > 0 {
+		err := roleCreateTags(ctx, conn, d.Id(), tags)
+
+		// If default tags only, continue. Otherwise, error.
+		if v, ok := d.GetOk(names.AttrTags); (!ok || len(v.(map[string]interface{})) == 0) && errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
+			return append(diags, resourceRoleRead(ctx, d, meta)...)
+		}
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting IAM Role (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	// Tags set on a new role are not always returned immediately.
+	if len(tags) > 0 {
+		if err := waitRoleTagsPropagated(ctx, conn, d.Id(), tags); err != nil {
+			return sdkdiag.AppendErrorf(diags, "waiting for IAM Role (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return append(diags, resourceRoleRead(ctx, d, meta)...)
+}
+
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	outputRaw, err := tfresource.RetryWhenNewResourceNotFound(ctx, rolePropagationTimeout(meta), func() (interface{}, error) {
+		return findRoleByNameRetryingThrottling(ctx, conn, d.Id())
+	}, d.IsNewResource())
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] IAM Role (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationRead, d.Id(), err))
+	}
+
+	role := outputRaw.(*iam.Role)
+
+	// occasionally, immediately after a role is created, AWS will give an ARN like AROAQ7SSZBKHREXAMPLE (unique ID)
+	if role, err = waitRoleARNIsNotUniqueID(ctx, conn, d.Id(), role); err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): waiting for valid ARN: %s", d.Id(), err)
+	}
+
+	d.Set("arn", role.Arn)
+	if v := role.CreateDate; v != nil {
+		d.Set("create_date", v.Format(time.RFC3339))
+		d.Set("create_date_unix", v.Unix())
+	} else {
+		d.Set("create_date", nil)
+		d.Set("create_date_unix", nil)
+	}
+	d.Set("description", role.Description)
+	d.Set("effective_name", role.RoleName)
+	d.Set("max_session_duration", role.MaxSessionDuration)
+	d.Set("max_session_duration_is_maximum", aws.Int64Value(role.MaxSessionDuration) == roleMaxSessionDurationMax)
+	d.Set("name", role.RoleName)
+	d.Set("name_prefix", create.NamePrefixFromName(aws.StringValue(role.RoleName)))
+	d.Set("path", role.Path)
+	d.Set("service_linked", roleIsServiceLinked(aws.StringValue(role.Path)))
+	d.Set("has_permissions_boundary", role.PermissionsBoundary != nil)
+	d.Set("permissions_boundary_name", "")
+	if role.PermissionsBoundary != nil {
+		d.Set("permissions_boundary", role.PermissionsBoundary.PermissionsBoundaryArn)
+		d.Set("permissions_boundary_name", rolePermissionsBoundaryName(aws.StringValue(role.PermissionsBoundary.PermissionsBoundaryArn)))
+	}
+	d.Set("permissions_boundary_document", "")
+	if d.Get("compute_boundary_document").(bool) {
+		permissionsBoundaryDocument, err := readRolePermissionsBoundaryDocument(ctx, conn, role)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) permissions boundary: %s", d.Id(), err)
+		}
+		d.Set("permissions_boundary_document", permissionsBoundaryDocument)
+	}
+	// Always set the block, even for a role that has never been used, so that its shape is stable.
+	roleLastUsed := role.RoleLastUsed
+	if roleLastUsed == nil {
+		roleLastUsed = &iam.RoleLastUsed{}
+	}
+	if err := d.Set("role_last_used", flattenRoleLastUsed(roleLastUsed)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting role_last_used: %s", err)
+	}
+	d.Set("unique_id", role.RoleId)
+
+	instanceProfileNames, err := readRoleInstanceProfileNames(ctx, conn, d.Id())
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) instance profiles: %s", d.Id(), err)
+	}
+	d.Set("instance_profile_names", aws.StringValueSlice(instanceProfileNames))
+
+	d.Set("instance_profile_arn", "")
+	if d.Get("create_instance_profile").(bool) {
+		instanceProfile, err := findRoleInstanceProfile(ctx, conn, d.Id(), instanceProfileNames)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) instance profile: %s", d.Id(), err)
+		}
+
+		if instanceProfile == nil {
+			// Plan to create the instance profile again.
+			log.Printf("[WARN] IAM Instance Profile (%s) for IAM Role (%s) not found or does not contain the role", d.Id(), d.Id())
+			d.Set("create_instance_profile", false)
+		} else {
+			d.Set("instance_profile_arn", instanceProfile.Arn)
+		}
+	}
+
+	assumeRolePolicy, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationRead, d.Id(), err))
+	}
+
+	// Statements managed outside of Terraform are not reflected in state.
+	managedAssumeRolePolicy := assumeRolePolicy
+	if v, ok := d.GetOk("assume_role_policy_ignore_statements"); ok && v.(*schema.Set).Len() > 0 {
+		managedAssumeRolePolicy, _, err = splitRoleAssumeRolePolicyStatements(assumeRolePolicy, flex.ExpandStringValueSet(v.(*schema.Set)))
+		if err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationRead, d.Id(), err))
+		}
+	}
+
+	policyToSet, err := verify.PolicyToSet(d.Get("assume_role_policy").(string), managedAssumeRolePolicy)
+	if err != nil {
+		return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationRead, d.Id(), err))
+	}
+
+	d.Set("assume_role_policy", policyToSet)
+
+	assumeRolePolicyHash, err := policyCanonicalHash(managedAssumeRolePolicy)
+	if err != nil {
+		log.Printf("[WARN] IAM Role (%s) assume role policy could not be hashed: %s", d.Id(), err)
+	}
+	d.Set("assume_role_policy_hash", assumeRolePolicyHash)
+
+	// Ignored statements still grant access, so the whole trust policy is analyzed.
+	trustsOrganization, err := policyTrustsOrganization(assumeRolePolicy)
+	if err != nil {
+		log.Printf("[WARN] IAM Role (%s) assume role policy could not be analyzed: %s", d.Id(), err)
+	}
+	d.Set("trusts_org_root", trustsOrganization)
+
+	principals, err := policyAllowedPrincipals(assumeRolePolicy)
+	if err != nil {
+		log.Printf("[WARN] IAM Role (%s) assume role policy principals could not be read: %s", d.Id(), err)
+	}
+	if err := d.Set("assume_role_principals", flattenRoleAssumeRolePrincipals(principals)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting assume_role_principals: %s", err)
+	}
+
+	// Listing every inline and attached policy is slow for roles with many policies, so skip it when
+	// refreshing after an update of a role whose configuration tracks neither. The trade-off is that
+	// the informational inline_policy, managed_policy_arns and policy count values are only updated on the next refresh.
+	if !roleReadSkipsPolicies(d) {
+		diags = append(diags, refreshRoleInlinePolicies(ctx, conn, d, aws.StringValue(role.RoleName))...)
+		if diags.HasError() {
+			return diags
+		}
+
+		managedPolicies, err := readRolePolicyAttachments(ctx, conn, aws.StringValue(role.RoleName))
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) managed policies: %s", d.Id(), err)
+		}
+		if d.Get("managed_policy_arns_exclusive").(bool) {
+			diags = appendRoleExternalManagedPolicyWarnings(diags, d, aws.StringValueSlice(managedPolicies))
+		} else {
+			// Only track the attachments this resource manages so that policies attached out of band are left alone.
+			managedPolicies = filterRoleManagedPolicies(managedPolicies, d.Get("managed_policy_arns").(*schema.Set))
+		}
+		d.Set("managed_policy_arns", managedPolicies)
+		d.Set("managed_policy_count", len(managedPolicies))
+	}
+
+	diags = append(diags, refreshRoleManagedPoliciesExceedingBoundary(ctx, conn, d, role)...)
+
+	tags := role.Tags
+	if d.Get("trim_tags").(bool) {
+		tags = untrimRoleTags(tags, d.Get(names.AttrTagsAll).(map[string]interface{}))
+	}
+	if d.Get("auto_tag_name").(bool) {
+		// The automatic Name tag is not configured in tags or default_tags, so keep it out of state.
+		tags = removeRoleAutoTags(tags, aws.StringValue(role.RoleName), d.Get(names.AttrTagsAll).(map[string]interface{}))
+	}
+
+	var adoptedTags map[string]string
+	if d.Get("adopt_external_tags").(bool) {
+		// Tags added outside Terraform are kept out of tags and tags_all so that they are not removed.
+		var externalTags []*iam.Tag
+		tags, externalTags = splitRoleExternalTags(tags, d.Get(names.AttrTagsAll).(map[string]interface{}))
+		adoptedTags = KeyValueTags(ctx, externalTags).IgnoreAWS().IgnoreConfig(roleIgnoreTagsConfig(ctx, d, meta.(*conns.AWSClient).IgnoreTagsConfig)).Map()
+	}
+	d.Set("adopted_tags", adoptedTags)
+
+	// The transparent tagging interceptor filters the tags set here with the ignore_tags configuration in Context.
+	if inContext, ok := tftags.FromContext(ctx); ok {
+		inContext.IgnoreConfig = roleIgnoreTagsConfig(ctx, d, inContext.IgnoreConfig)
+	}
+
+	setTagsOut(ctx, tags)
+
+	return diags
+}
+
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	// The individual attribute updates are independent of each other and are run concurrently.
+	// schema.ResourceData is not safe for concurrent use, so all values are read up front.
+	// IAM can reject a change to a role while another is in progress, so each role-level call retries ConcurrentModification.
+	var updates []func() error
+
+	if d.HasChanges("assume_role_policy", "assume_role_policy_document", "trusted_services") {
+		assumeRolePolicy, err := expandRoleAssumeRolePolicy(d)
+		if err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+		}
+
+		// Carry over any ignored statements that are currently in the role's trust policy.
+		if v, ok := d.GetOk("assume_role_policy_ignore_statements"); ok && v.(*schema.Set).Len() > 0 {
+			assumeRolePolicy, err = addRoleAssumeRolePolicyIgnoredStatements(ctx, conn, d.Id(), assumeRolePolicy, flex.ExpandStringValueSet(v.(*schema.Set)))
+			if err != nil {
+				return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+			}
+		}
+
+		input := &iam.UpdateAssumeRolePolicyInput{
+			RoleName:       aws.String(d.Id()),
+			PolicyDocument: aws.String(assumeRolePolicy),
+		}
+
+		timeout := rolePropagationTimeout(meta)
+		waitForPropagation := d.Get("assume_role_policy_wait_for_propagation").(bool)
+
+		updates = append(updates, func() error {
+			_, err := tfresource.RetryWhen(ctx, timeout,
+				func() (interface{}, error) {
+					return conn.UpdateAssumeRolePolicyWithContext(ctx, input)
+				},
+				func(err error) (bool, error) {
+					if tfawserr.ErrMessageContains(err, iam.ErrCodeMalformedPolicyDocumentException, "Invalid principal in policy") {
+						return true, err
+					}
+
+					if tfawserr.ErrCodeEquals(err, iam.ErrCodeConcurrentModificationException) {
+						return true, err
+					}
+
+					return false, err
+				},
+			)
+
+			if err != nil {
+				return fmt.Errorf("updating assume role policy: %w", roleAssumeRolePolicyError(assumeRolePolicy, err))
+			}
+
+			if waitForPropagation {
+				if err := waitRoleAssumeRolePolicyPropagated(ctx, conn, aws.StringValue(input.RoleName), assumeRolePolicy, timeout); err != nil {
+					return fmt.Errorf("waiting for assume role policy propagation: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if d.HasChange("create_instance_profile") {
+		roleName, path := d.Id(), d.Get("path").(string)
+		timeout := rolePropagationTimeout(meta)
+
+		if d.Get("create_instance_profile").(bool) {
+			updates = append(updates, func() error {
+				return createRoleInstanceProfile(ctx, conn, roleName, path, timeout)
+			})
+		} else {
+			updates = append(updates, func() error {
+				return deleteRoleInstanceProfile(ctx, conn, roleName)
+			})
+		}
+	}
+
+	if d.HasChange("description") {
+		description, err := expandRoleDescription(d.Get("description").(string), d.Get("description_on_overflow").(string))
+		if err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+		}
+
+		input := &iam.UpdateRoleDescriptionInput{
+			RoleName:    aws.String(d.Id()),
+			Description: aws.String(description),
+		}
+
+		timeout := rolePropagationTimeout(meta)
+
+		updates = append(updates, func() error {
+			if err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+				return conn.UpdateRoleDescriptionWithContext(ctx, input)
+			}); err != nil {
+				return fmt.Errorf("updating description: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	if d.HasChange("max_session_duration") {
+		input := &iam.UpdateRoleInput{
+			RoleName:           aws.String(d.Id()),
+			MaxSessionDuration: aws.Int64(int64(d.Get("max_session_duration").(int))),
+		}
+
+		timeout := rolePropagationTimeout(meta)
+
+		updates = append(updates, func() error {
+			if err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+				return conn.UpdateRoleWithContext(ctx, input)
+			}); err != nil {
+				return fmt.Errorf("updating MaxSessionDuration: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	if d.HasChange("permissions_boundary") {
+		o, n := d.GetChange("permissions_boundary")
+		if update := rolePermissionsBoundaryUpdate(ctx, conn, d.Id(), o.(string), n.(string), rolePropagationTimeout(meta)); update != nil {
+			updates = append(updates, update)
+		}
+	}
+
+	if d.HasChange("inline_policy") && inlinePoliciesActualDiff(d) {
+		roleName := d.Get("name").(string)
+
+		o, n := d.GetChange("inline_policy")
+
+		if o == nil {
+			o = new(schema.Set)
+		}
+
+		if n == nil {
+			n = new(schema.Set)
+		}
+
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		remove := os.Difference(ns).List()
+		add := ns.Difference(os).List()
+
+		var policyNames []*string
+		for _, policy := range remove {
+			tfMap, ok := policy.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			if v, ok := tfMap["name"].(string); ok && v != "" {
+				policyNames = append(policyNames, aws.String(tfMap["name"].(string)))
+			}
+		}
+
+		policies := expandRoleInlinePolicies(roleName, add)
+		if d.Get("normalize_inline_policies").(bool) {
+			if err := normalizeRoleInlinePolicies(policies); err != nil {
+				return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+			}
+		}
+		timeout := rolePropagationTimeout(meta)
+
+		updates = append(updates, func() error {
+			if err := deleteRoleInlinePolicies(ctx, conn, roleName, policyNames, timeout); err != nil {
+				return err
+			}
+
+			return addRoleInlinePolicies(ctx, policies, meta)
+		})
+	}
+
+	if d.HasChange("inline_policies") {
+		roleName := d.Get("name").(string)
+
+		o, n := d.GetChange("inline_policies")
+		policyNames, policies := expandRoleInlinePoliciesMapChanges(roleName, o.(map[string]interface{}), n.(map[string]interface{}))
+		if d.Get("normalize_inline_policies").(bool) {
+			if err := normalizeRoleInlinePolicies(policies); err != nil {
+				return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+			}
+		}
+		timeout := rolePropagationTimeout(meta)
+
+		updates = append(updates, func() error {
+			if err := deleteRoleInlinePolicies(ctx, conn, roleName, policyNames, timeout); err != nil {
+				return err
+			}
+
+			return addRoleInlinePolicies(ctx, policies, meta)
+		})
+	}
+
+	if d.HasChange("managed_policy_arns") {
+		roleName := d.Get("name").(string)
+
+		o, n := d.GetChange("managed_policy_arns")
+		if o == nil {
+			o = new(schema.Set)
+		}
+		if n == nil {
+			n = new(schema.Set)
+		}
+
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+		remove := flex.ExpandStringSet(os.Difference(ns))
+		add := flex.ExpandStringSet(ns.Difference(os))
+		remove, diags = roleImportedManagedPolicyDetachments(diags, roleName, d.Get("managed_policy_arns_import_pending").(bool), remove)
+		timeout := rolePropagationTimeout(meta)
+
+		updates = append(updates, func() error {
+			return updateRoleManagedPolicies(ctx, conn, roleName, add, remove, timeout)
+		})
+	}
+
+	if err := runRoleUpdates(updates); err != nil {
+		return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationUpdate, d.Id(), err))
+	}
+
+	if d.Get("managed_policy_arns_import_pending").(bool) {
+		d.Set("managed_policy_arns_import_pending", false)
+	}
+
+	if d.HasChanges("managed_policy_arns", "permissions_boundary") {
+		diags = appendRoleBoundaryCappedPolicyWarnings(diags, d.Id(), d.Get("permissions_boundary").(string), flex.ExpandStringValueSet(d.Get("managed_policy_arns").(*schema.Set)))
+	}
+
+	if d.HasChanges("tags_all", "auto_tag_name") {
+		o, n := d.GetChange("tags_all")
+		if d.Get("trim_tags").(bool) {
+			o, n = trimRoleTagsMap(o.(map[string]interface{})), trimRoleTagsMap(n.(map[string]interface{}))
+		}
+
+		// Reconcile the automatic Name tag, which is not part of tags_all.
+		if oa, na := d.GetChange("auto_tag_name"); oa.(bool) || na.(bool) {
+			ignoreConfig := roleIgnoreTagsConfig(ctx, d, meta.(*conns.AWSClient).IgnoreTagsConfig)
+			oldTags, newTags := tftags.New(ctx, o), tftags.New(ctx, n)
+			if oa.(bool) {
+				oldTags = oldTags.Merge(roleAutoTags(ignoreConfig, d.Id(), oldTags))
+			}
+			if na.(bool) {
+				newTags = newTags.Merge(roleAutoTags(ignoreConfig, d.Id(), newTags))
+			}
+			o, n = oldTags, newTags
+		}
+
+		// TagRole and UntagRole can fail with ConcurrentModification while other changes
+		// are being made to the same role, so tags are updated after the other updates.
+		err := roleUpdateTags(ctx, conn, d.Id(), o, n)
+
+		// Some partitions (e.g. ISO) may not support tagging.
+		if errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
+			return append(diags, resourceRoleRead(ctx, d, meta)...)
+		}
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) tags: %s", d.Id(), err)
+		}
+	}
+
+	return append(diags, resourceRoleRead(ctx, d, meta)...)
+}
+
+// runRoleUpdates runs the specified independent role updates concurrently, aggregating any errors.
+func runRoleUpdates(updates []func() error) error {
+	var g multierror.Group
+
+	for _, update := range updates {
+		g.Go(update)
+	}
+
+	return g.Wait().ErrorOrNil()
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v, ok := d.GetOk("skip_destroy"); ok && v.(bool) {
+		log.Printf("[DEBUG] Retaining IAM Role (%s)", d.Id())
+		return diags
+	}
+
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	// Avoid detaching anything from a role that has already been deleted, e.g. during a large destroy.
+	// The current tags are also needed for the deletion protection check.
+	role, err := FindRoleByName(ctx, conn, d.Id())
+
+	if tfresource.NotFound(err) {
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", d.Id(), err)
+	}
+
+	if key := roleDeletionProtectionTagKey(meta); key != "" && roleDeletionProtected(role, key) {
+		return sdkdiag.AppendErrorf(diags, "deleting IAM Role (%s): role is protected from deletion by its %s tag. Remove the tag first", d.Id(), key)
+	}
+
+	if d.Get("forbid_external_inline_policy_deletion").(bool) {
+		policyNames, err := readRolePolicyNames(ctx, conn, d.Id())
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", d.Id(), err)
+		}
+
+		if external := roleExternalInlinePolicyNames(aws.StringValueSlice(policyNames), d.Get("inline_policy").(*schema.Set), d.Get("inline_policies").(map[string]interface{})); len(external) > 0 {
+			return sdkdiag.AppendErrorf(diags, "deleting IAM Role (%s): role has inline policies that are not managed by this resource, and would be deleted with it: %s. Delete them first, or set forbid_external_inline_policy_deletion to false", d.Id(), strings.Join(external, ", "))
+		}
+	}
+
+	if d.Get("create_instance_profile").(bool) {
+		if err := deleteRoleInstanceProfile(ctx, conn, d.Id()); err != nil {
+			return sdkdiag.AppendFromErr(diags, newRoleError(RoleOperationDelete, d.Id(), err))
+		}
+	}
+
+	hasInline := false
+	if v, ok := d.GetOk("inline_policy"); ok && v.(*schema.Set).Len() > 0 {
+		hasInline = true
+	}
+	if v, ok := d.GetOk("inline_policies"); ok && len(v.(map[string]interface{})) > 0 {
+		hasInline = true
+	}
+
+	hasManaged := false
+	if v, ok := d.GetOk("managed_policy_arns"); ok && v.(*schema.Set).Len() > 0 {
+		hasManaged = true
+	}
+
+	// With destroy_managed_inline_policies_only, inline policies added outside this resource are left in place.
+	var inlinePolicyNames []string
+	if d.Get("destroy_managed_inline_policies_only").(bool) {
+		inlinePolicyNames = roleManagedInlinePolicyNames(d.Get("inline_policy").(*schema.Set), d.Get("inline_policies").(map[string]interface{}))
+	}
+
+	err = DeleteRole(ctx, conn, d.Id(), d.Get("force_detach_policies").(bool), hasInline, hasManaged, inlinePolicyNames, rolePropagationTimeout(meta))
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return diags
+	}
+
+	if err != nil {
+		// DeleteRole's error is already a *RoleError.
+		return sdkdiag.AppendFromErr(diags, err)
+	}
+
+	if err := waitRoleDeleted(ctx, conn, d.Id()); err != nil {
+		return sdkdiag.AppendErrorf(diags, "waiting for IAM Role (%s) delete: %s", d.Id(), err)
+	}
+
+	return diags
+}
+
+// filterRoleInlinePolicyNames returns, sorted, the policyNames that are (keep is true) or are not (keep is false) in names.
+func filterRoleInlinePolicyNames(policyNames, names []string, keep bool) []string {
+	var output []string
+	for _, policyName := range policyNames {
+		if slices.Contains(names, policyName) == keep {
+			output = append(output, policyName)
+		}
+	}
+
+	slices.Sort(output)
+
+	return output
+}
+
+// roleManagedInlinePolicyNames returns, sorted, the names of the inline policies in the resource's
+// `inline_policy` blocks and `inline_policies` map. The result is never nil.
+func roleManagedInlinePolicyNames(inlinePolicies *schema.Set, inlinePoliciesMap map[string]interface{}) []string {
+	names := make([]string, 0, inlinePolicies.Len()+len(inlinePoliciesMap))
+	for _, tfMapRaw := range inlinePolicies.List() {
+		if tfMap, ok := tfMapRaw.(map[string]interface{}); ok && tfMap["name"].(string) != "" {
+			names = append(names, tfMap["name"].(string))
+		}
+	}
+	for name := range inlinePoliciesMap {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// roleExternalInlinePolicyNames returns, sorted, the names of the role's inline policies that are not
+// in the resource's `inline_policy` blocks or `inline_policies` map.
+func roleExternalInlinePolicyNames(policyNames []string, inlinePolicies *schema.Set, inlinePoliciesMap map[string]interface{}) []string {
+	return filterRoleInlinePolicyNames(policyNames, roleManagedInlinePolicyNames(inlinePolicies, inlinePoliciesMap), false)
+}
+
+// roleIgnoreTagsConfig returns the ignore_tags configuration for the role: the resource's `ignore_tag_keys`
+// and `ignore_tag_prefixes` if either is set, otherwise the provider's ignoreConfig.
+func roleIgnoreTagsConfig(ctx context.Context, d *schema.ResourceData, ignoreConfig *tftags.IgnoreConfig) *tftags.IgnoreConfig {
+	keys, prefixes := d.Get("ignore_tag_keys").(*schema.Set), d.Get("ignore_tag_prefixes").(*schema.Set)
+	if keys.Len() == 0 && prefixes.Len() == 0 {
+		return ignoreConfig
+	}
+
+	return &tftags.IgnoreConfig{
+		Keys:        tftags.New(ctx, keys.List()),
+		KeyPrefixes: tftags.New(ctx, prefixes.List()),
+	}
+}
+
+// roleServiceLinkedOnlyServices are services, keyed by the first label of their service principal, that only
+// assume their service-linked roles, so a role that you create for them is never used.
+var roleServiceLinkedOnlyServices = map[string]struct{}{
+	"elasticache":          {},
+	"elasticloadbalancing": {},
+	"guardduty":            {},
+	"inspector2":           {},
+	"securityhub":          {},
+}
+
+// roleServiceLinkedOnlyPrincipals returns, sorted, the service principals of a trust policy if they are its only
+// principals and all are services in roleServiceLinkedOnlyServices. Otherwise it returns none.
+func roleServiceLinkedOnlyPrincipals(policy string) ([]string, error) {
+	principals, err := policyAllowedPrincipals(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(principals) != 1 || principals[0].Type != "Service" {
+		return nil, nil
+	}
+
+	services := principals[0].Identifiers.([]string)
+	for _, service := range services {
+		label, _, _ := strings.Cut(service, ".")
+		if _, ok := roleServiceLinkedOnlyServices[label]; !ok {
+			return nil, nil
+		}
+	}
+
+	return services, nil
+}
+
+// normalizeRolePath adds the trailing slash that IAM requires to a path that begins with a slash but does not end with one.
+func normalizeRolePath(path string) string {
+	if strings.HasPrefix(path, "/") && !strings.HasSuffix(path, "/") {
+		return path + "/"
+	}
+
+	return path
+}
+
+// roleIsServiceLinked returns whether a role with the specified path is a service-linked role.
+func roleIsServiceLinked(path string) bool {
+	return strings.HasPrefix(path, roleServiceLinkedPathPrefix)
+}
+
+// rolePropagationTimeout returns how long to wait for IAM changes to propagate:
+// the provider's `iam_propagation_timeout` if set, otherwise propagationTimeout.
+func rolePropagationTimeout(meta interface{}) time.Duration {
+	if client, ok := meta.(*conns.AWSClient); ok && client.IAMPropagationTimeout > 0 {
+		return client.IAMPropagationTimeout
+	}
+
+	return propagationTimeout
+}
+
+// roleDeletionProtectionTagKey returns the provider's iam_role_deletion_protection_tag, or "" if it is not set.
+func roleDeletionProtectionTagKey(meta interface{}) string {
+	if client, ok := meta.(*conns.AWSClient); ok {
+		return client.IAMRoleDeletionTag
+	}
+
+	return ""
+}
+
+// roleDeletionProtected reports whether the role has the key tag set to true.
+// Pass the role as it currently is in IAM rather than state, so that a tag added outside Terraform is honoured.
+func roleDeletionProtected(role *iam.Role, key string) bool {
+	for _, tag := range role.Tags {
+		if aws.StringValue(tag.Key) == key {
+			return strings.EqualFold(aws.StringValue(tag.Value), "true")
+		}
+	}
+
+	return false
+}
+
+// DeleteRole deletes the role, first detaching what is selected. While IAM reports that the role still
+// has attachments, the delete is retried for up to timeout. If inlinePolicyNames is not nil, only the
+// inline policies with those names are deleted, and the delete fails at once if others remain. Any error is a *RoleError.
+func DeleteRole(ctx context.Context, conn *iam.IAM, roleName string, forceDetach, hasInline, hasManaged bool, inlinePolicyNames []string, timeout time.Duration) error {
+	if err := deleteRole(ctx, conn, roleName, forceDetach, hasInline, hasManaged, inlinePolicyNames, timeout); err != nil {
+		return newRoleError(RoleOperationDelete, roleName, err)
+	}
+
+	return nil
+}
+
+func deleteRole(ctx context.Context, conn *iam.IAM, roleName string, forceDetach, hasInline, hasManaged bool, inlinePolicyNames []string, timeout time.Duration) error {
+	if err := deleteRoleInstanceProfiles(ctx, conn, roleName, timeout); err != nil {
+		return fmt.Errorf("removing IAM Role (%s) from instance profiles: %w", roleName, err)
+	}
+
+	if forceDetach || hasManaged {
+		managedPolicies, err := readRolePolicyAttachments(ctx, conn, roleName)
+		if err != nil {
+			return fmt.Errorf("reading IAM Role (%s) managed policies: %w", roleName, err)
+		}
+
+		if err := deleteRolePolicyAttachments(ctx, conn, roleName, managedPolicies, timeout); err != nil {
+			return fmt.Errorf("detaching IAM Role (%s) managed policies: %w", roleName, err)
+		}
+	}
+
+	if forceDetach || hasInline {
+		inlinePolicies, err := readRolePolicyNames(ctx, conn, roleName)
+		if err != nil {
+			return fmt.Errorf("reading IAM Role (%s) inline policies: %w", roleName, err)
+		}
+
+		if inlinePolicyNames != nil {
+			inlinePolicies = aws.StringSlice(filterRoleInlinePolicyNames(aws.StringValueSlice(inlinePolicies), inlinePolicyNames, true))
+		}
+
+		if err := deleteRoleInlinePolicies(ctx, conn, roleName, inlinePolicies, timeout); err != nil {
+			return fmt.Errorf("removing IAM Role (%s) inline policies: %w", roleName, err)
+		}
+	}
+
+	deleteRoleInput := &iam.DeleteRoleInput{
+		RoleName: aws.String(roleName),
+	}
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		_, err := conn.DeleteRoleWithContext(ctx, deleteRoleInput)
+		if err != nil {
+			if tfawserr.ErrCodeEquals(err, iam.ErrCodeDeleteConflictException) {
+				// Inline policies that were deliberately kept will not go away by waiting.
+				if inlinePolicyNames != nil {
+					if remaining, readErr := readRolePolicyNames(ctx, conn, roleName); readErr == nil {
+						if external := filterRoleInlinePolicyNames(aws.StringValueSlice(remaining), inlinePolicyNames, false); len(external) > 0 {
+							return retry.NonRetryableError(fmt.Errorf("role still has inline policies that are not managed by this resource: %s. IAM cannot delete a role with inline policies; delete them first: %w", strings.Join(external, ", "), err))
+						}
+					}
+				}
+
+				return retry.RetryableError(err)
+			}
+
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	if tfresource.TimedOut(err) {
+		_, err = conn.DeleteRoleWithContext(ctx, deleteRoleInput)
+	}
+
+	return err
+}
+
+func readRoleInstanceProfileNames(ctx context.Context, conn *iam.IAM, roleName string) ([]*string, error) {
+	instanceProfileNames := make([]*string, 0)
+	input := &iam.ListInstanceProfilesForRoleInput{
+		RoleName: aws.String(roleName),
+	}
+
+	err := conn.ListInstanceProfilesForRolePagesWithContext(ctx, input, func(page *iam.ListInstanceProfilesForRoleOutput, lastPage bool) bool {
+		for _, v := range page.InstanceProfiles {
+			instanceProfileNames = append(instanceProfileNames, v.InstanceProfileName)
+		}
+		return !lastPage
+	})
+	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, err
+	}
+
+	return instanceProfileNames, nil
+}
+
+func deleteRoleInstanceProfiles(ctx context.Context, conn *iam.IAM, roleName string, timeout time.Duration) error {
+	instanceProfileNames, err := readRoleInstanceProfileNames(ctx, conn, roleName)
+	if err != nil {
+		return err
+	}
+
+	// Loop and remove this Role from any Profiles
+	for _, instanceProfileName := range instanceProfileNames {
+		input := &iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: instanceProfileName,
+			RoleName:            aws.String(roleName),
+		}
+
+		// Profiles that were only just created or modified can transiently report a conflict.
+		_, err := tfresource.RetryWhen(ctx, timeout,
+			func() (interface{}, error) {
+				return conn.RemoveRoleFromInstanceProfileWithContext(ctx, input)
+			},
+			func(err error) (bool, error) {
+				return isRoleDetachRetryable(err), err
+			},
+		)
+		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("removing IAM Role (%s) from IAM Instance Profile (%s): %w", roleName, aws.StringValue(instanceProfileName), err)
+		}
+	}
+
+	return nil
+}
+
+// createRoleInstanceProfile creates an instance profile with the role's name and path and adds the role to it.
+// An existing instance profile with that name is used instead, if it is empty or already contains the role.
+func createRoleInstanceProfile(ctx context.Context, conn *iam.IAM, roleName, path string, timeout time.Duration) error {
+	input := &iam.CreateInstanceProfileInput{
+		InstanceProfileName: aws.String(roleName),
+		Path:                aws.String(path),
+	}
+
+	_, err := conn.CreateInstanceProfileWithContext(ctx, input)
+
+	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeEntityAlreadyExistsException) {
+		return fmt.Errorf("creating IAM Instance Profile (%s): %w", roleName, err)
+	}
+
+	outputRaw, err := tfresource.RetryWhenNotFound(ctx, timeout, func() (interface{}, error) {
+		return FindInstanceProfileByName(ctx, conn, roleName)
+	})
+
+	if err != nil {
+		return fmt.Errorf("reading IAM Instance Profile (%s): %w", roleName, err)
+	}
+
+	// An instance profile can contain only one role.
+	if roles := outputRaw.(*iam.InstanceProfile).Roles; len(roles) > 0 {
+		if v := aws.StringValue(roles[0].RoleName); v != roleName {
+			return fmt.Errorf("IAM Instance Profile (%s) already contains IAM Role (%s)", roleName, v)
+		}
+
+		return nil
+	}
+
+	return instanceProfileAddRole(ctx, conn, roleName, roleName)
+}
+
+// findRoleInstanceProfile returns the instance profile with the role's name if it contains the role, or nil.
+// instanceProfileNames are the names of the instance profiles that contain the role.
+func findRoleInstanceProfile(ctx context.Context, conn *iam.IAM, roleName string, instanceProfileNames []*string) (*iam.InstanceProfile, error) {
+	if !slices.Contains(aws.StringValueSlice(instanceProfileNames), roleName) {
+		return nil, nil
+	}
+
+	instanceProfile, err := FindInstanceProfileByName(ctx, conn, roleName)
+
+	if tfresource.NotFound(err) {
+		return nil, nil
+	}
+
+	return instanceProfile, err
+}
+
+// deleteRoleInstanceProfile removes the role from the instance profile with its name and deletes the instance profile.
+func deleteRoleInstanceProfile(ctx context.Context, conn *iam.IAM, roleName string) error {
+	if err := instanceProfileRemoveRole(ctx, conn, roleName, roleName); err != nil {
+		return err
+	}
+
+	_, err := conn.DeleteInstanceProfileWithContext(ctx, &iam.DeleteInstanceProfileInput{
+		InstanceProfileName: aws.String(roleName),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("deleting IAM Instance Profile (%s): %w", roleName, err)
+	}
+
+	return nil
+}
+
+func retryCreateRole(ctx context.Context, conn *iam.IAM, input *iam.CreateRoleInput, timeout time.Duration) (*iam.CreateRoleOutput, error) {
+	start := time.Now()
+	outputRaw, err := tfresource.RetryWhen(ctx, timeout,
+		func() (interface{}, error) {
+			return conn.CreateRoleWithContext(ctx, input)
+		},
+		func(err error) (bool, error) {
+			if tfawserr.ErrMessageContains(err, iam.ErrCodeMalformedPolicyDocumentException, "Invalid principal in policy") {
+				return true, err
+			}
+
+			// A role with the same name that was just deleted can still be reported as existing.
+			if tfawserr.ErrCodeEquals(err, iam.ErrCodeEntityAlreadyExistsException) && time.Since(start) < roleEntityAlreadyExistsTimeout {
+				return true, err
+			}
+
+			return false, err
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output, ok := outputRaw.(*iam.CreateRoleOutput)
+	if !ok || output == nil || aws.StringValue(output.Role.RoleName) == "" {
+		return nil, fmt.Errorf("create IAM role (%s) returned an empty result", aws.StringValue(input.RoleName))
+	}
+
+	return output, err
+}
+
+// adoptRole returns the existing role with the same name as the role to be created,
+// provided that it has the same path and an equivalent trust policy.
+func adoptRole(ctx context.Context, conn *iam.IAM, input *iam.CreateRoleInput) (*iam.Role, error) {
+	roleName := aws.StringValue(input.RoleName)
+	role, err := FindRoleByName(ctx, conn, roleName)
+
+	if err != nil {
+		return nil, fmt.Errorf("reading existing IAM Role (%s): %w", roleName, err)
+	}
+
+	if aws.StringValue(role.Path) != aws.StringValue(input.Path) {
+		return nil, fmt.Errorf("existing IAM Role (%s) has path %s, not %s; not adopting it", roleName, aws.StringValue(role.Path), aws.StringValue(input.Path))
+	}
+
+	assumeRolePolicy, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing IAM Role (%s) assume role policy: %w", roleName, err)
+	}
+
+	if equivalent, err := awspolicy.PoliciesAreEquivalent(assumeRolePolicy, aws.StringValue(input.AssumeRolePolicyDocument)); err != nil || !equivalent {
+		return nil, fmt.Errorf("existing IAM Role (%s) has a different assume role policy; not adopting it", roleName)
+	}
+
+	return role, nil
+}
+
+func FindRoleByName(ctx context.Context, conn *iam.IAM, name string) (*iam.Role, error) {
+	input := &iam.GetRoleInput{
+		RoleName: aws.String(name),
+	}
+
+	output, err := conn.GetRoleWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, &retry.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || output.Role == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	return output.Role, nil
+}
+
+// findRoleByNameRetryingThrottling calls FindRoleByName, retrying with backoff for up to roleReadThrottlingTimeout
+// while IAM throttles requests. A role that does not exist is reported at once.
+func findRoleByNameRetryingThrottling(ctx context.Context, conn *iam.IAM, name string) (*iam.Role, error) {
+	outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, roleReadThrottlingTimeout, func() (interface{}, error) {
+		return FindRoleByName(ctx, conn, name)
+	}, "Throttling")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outputRaw.(*iam.Role), nil
+}
+
+func readRolePolicyAttachments(ctx context.Context, conn *iam.IAM, roleName string) ([]*string, error) {
+	managedPolicies := make([]*string, 0)
+	input := &iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}
+
+	err := conn.ListAttachedRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+		for _, v := range page.AttachedPolicies {
+			managedPolicies = append(managedPolicies, v.PolicyArn)
+		}
+		return !lastPage
+	})
+	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, err
+	}
+
+	return managedPolicies, nil
+}
+
+// deleteRolePolicyAttachments detaches the specified managed policies from the role.
+// Like attachment, detachment is run concurrently and all errors are aggregated.
+func deleteRolePolicyAttachments(ctx context.Context, conn *iam.IAM, roleName string, managedPolicies []*string, timeout time.Duration) error {
+	return forEachRoleManagedPolicy(aws.StringValueSlice(managedPolicies), func(policyARN string) error {
+		input := &iam.DetachRolePolicyInput{
+			PolicyArn: aws.String(policyARN),
+			RoleName:  aws.String(roleName),
+		}
+
+		// The attachment may still be settling, e.g. if it was only just created.
+		_, err := tfresource.RetryWhen(ctx, timeout,
+			func() (interface{}, error) {
+				return conn.DetachRolePolicyWithContext(ctx, input)
+			},
+			func(err error) (bool, error) {
+				return isRoleDetachRetryable(err), err
+			},
+		)
+		// The policy, or its attachment, may have been deleted concurrently.
+		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("detaching managed policy (%s): %w", policyARN, err)
+		}
+
+		return nil
+	})
+}
+
+// isRoleDetachRetryable returns whether an error detaching a policy or instance profile from a role is transient.
+func isRoleDetachRetryable(err error) bool {
+	return tfawserr.ErrCodeEquals(err, iam.ErrCodeConcurrentModificationException, iam.ErrCodeDeleteConflictException, "Throttling")
+}
+
+// retryRoleConcurrentModification calls f, retrying while IAM reports a concurrent modification of the role.
+// The updates run by resourceRoleUpdate each change the same role, so they can conflict with each other.
+func retryRoleConcurrentModification(ctx context.Context, timeout time.Duration, f func() (interface{}, error)) error {
+	_, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, timeout, f, iam.ErrCodeConcurrentModificationException)
+
+	return err
+}
+
+func readRolePolicyNames(ctx context.Context, conn *iam.IAM, roleName string) ([]*string, error) {
+	inlinePolicies := make([]*string, 0)
+	input := &iam.ListRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}
+
+	err := conn.ListRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
+		inlinePolicies = append(inlinePolicies, page.PolicyNames...)
+		return !lastPage
+	})
+
+	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, err
+	}
+
+	return inlinePolicies, nil
+}
+
+func deleteRoleInlinePolicies(ctx context.Context, conn *iam.IAM, roleName string, policyNames []*string, timeout time.Duration) error {
+	for _, name := range policyNames {
+		if len(aws.StringValue(name)) == 0 {
+			continue
+		}
+
+		input := &iam.DeleteRolePolicyInput{
+			PolicyName: name,
+			RoleName:   aws.String(roleName),
+		}
+
+		err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+			return conn.DeleteRolePolicyWithContext(ctx, input)
+		})
+		// The policy may have been deleted concurrently; the remaining policies must still be deleted.
+		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("removing inline policy (%s): %w", aws.StringValue(name), err)
+		}
+	}
+
+	return nil
+}
+
+func flattenRoleInlinePolicy(apiObject *iam.PutRolePolicyInput) map[string]interface{} {
+	if apiObject == nil {
+		return nil
+	}
+
+	tfMap := map[string]interface{}{}
+
+	tfMap["name"] = aws.StringValue(apiObject.PolicyName)
+	tfMap["policy"] = aws.StringValue(apiObject.PolicyDocument)
+
+	return tfMap
+}
+
+func flattenRoleInlinePolicies(apiObjects []*iam.PutRolePolicyInput) []interface{} {
+	if len(apiObjects) == 0 {
+		return nil
+	}
+
+	var tfList []interface{}
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		tfList = append(tfList, flattenRoleInlinePolicy(apiObject))
+	}
+
+	return tfList
+}
+
+func expandRoleInlinePolicy(roleName string, tfMap map[string]interface{}) *iam.PutRolePolicyInput {
+	if tfMap == nil {
+		return nil
+	}
+
+	apiObject := &iam.PutRolePolicyInput{}
+
+	namePolicy := false
+
+	if v, ok := tfMap["name"].(string); ok && v != "" {
+		apiObject.PolicyName = aws.String(v)
+		namePolicy = true
+	}
+
+	if v, ok := tfMap["policy"].(string); ok && v != "" {
+		apiObject.PolicyDocument = aws.String(v)
+		namePolicy = true
+	}
+
+	if namePolicy {
+		apiObject.RoleName = aws.String(roleName)
+	}
+
+	return apiObject
+}
+
+// expandRoleInlinePoliciesMapChanges compares the old and new values of `inline_policies`, returning the names of the
+// policies to delete and, sorted by name, the policies to add or replace.
+func expandRoleInlinePoliciesMapChanges(roleName string, o, n map[string]interface{}) ([]*string, []*iam.PutRolePolicyInput) {
+	var policyNames []*string
+	for name := range o {
+		if _, ok := n[name]; !ok {
+			policyNames = append(policyNames, aws.String(name))
+		}
+	}
+
+	var names []string
+	for name, policy := range n {
+		if v, ok := o[name]; !ok || v.(string) != policy.(string) {
+			names = append(names, name)
+		}
+	}
+
+	slices.SortFunc(policyNames, func(a, b *string) bool { return aws.StringValue(a) < aws.StringValue(b) })
+	slices.Sort(names)
+
+	policies := make([]*iam.PutRolePolicyInput, 0, len(names))
+	for _, name := range names {
+		policies = append(policies, &iam.PutRolePolicyInput{
+			PolicyDocument: aws.String(n[name].(string)),
+			PolicyName:     aws.String(name),
+			RoleName:       aws.String(roleName),
+		})
+	}
+
+	return policyNames, policies
+}
+
+// flattenRoleInlinePoliciesMap returns the role's inline policies as a map of name to policy document.
+// A document equivalent to the one in prior is replaced by the prior document, so that an equivalent policy is not a diff.
+func flattenRoleInlinePoliciesMap(apiObjects []*iam.PutRolePolicyInput, prior map[string]interface{}) (map[string]interface{}, error) {
+	tfMap := make(map[string]interface{}, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		name, policy := aws.StringValue(apiObject.PolicyName), aws.StringValue(apiObject.PolicyDocument)
+
+		if v, ok := prior[name].(string); ok {
+			var err error
+			if policy, err = verify.PolicyToSet(v, policy); err != nil {
+				return nil, fmt.Errorf("inline policy (%s): %w", name, err)
+			}
+		}
+
+		tfMap[name] = policy
+	}
+
+	return tfMap, nil
+}
+
+func expandRoleInlinePolicies(roleName string, tfList []interface{}) []*iam.PutRolePolicyInput {
+	if len(tfList) == 0 {
+		return nil
+	}
+
+	var apiObjects []*iam.PutRolePolicyInput
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiObject := expandRoleInlinePolicy(roleName, tfMap)
+
+		if apiObject == nil {
+			continue
+		}
+
+		apiObjects = append(apiObjects, apiObject)
+	}
+
+	return apiObjects
+}
+
+// normalizeRoleInlinePolicies replaces each inline policy document with its normalized form, compact JSON
+// with the Version element first, so that the document written to IAM is the one stored in state.
+func normalizeRoleInlinePolicies(policies []*iam.PutRolePolicyInput) error {
+	for _, policy := range policies {
+		if aws.StringValue(policy.PolicyDocument) == "" {
+			continue
+		}
+
+		document, err := verify.LegacyPolicyNormalize(aws.StringValue(policy.PolicyDocument))
+		if err != nil {
+			return fmt.Errorf("inline policy (%s): %w", aws.StringValue(policy.PolicyName), err)
+		}
+
+		policy.PolicyDocument = aws.String(document)
+	}
+
+	return nil
+}
+
+func addRoleInlinePolicies(ctx context.Context, policies []*iam.PutRolePolicyInput, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	return putRoleInlinePolicies(ctx, conn, policies, false, rolePropagationTimeout(meta))
+}
+
+// checkRoleExistingInlinePolicies logs, for each inline policy to be added when creating the role, whether it is new
+// or overwrites one of the role's existing inline policies. If failOnExisting is true, overwriting is an error instead.
+func checkRoleExistingInlinePolicies(roleName string, existingPolicyNames []string, policies []*iam.PutRolePolicyInput, failOnExisting bool) error {
+	var overwritten []string
+
+	for _, policy := range policies {
+		policyName := aws.StringValue(policy.PolicyName)
+		if policyName == "" || aws.StringValue(policy.PolicyDocument) == "" {
+			continue
+		}
+
+		if !slices.Contains(existingPolicyNames, policyName) {
+			log.Printf("[DEBUG] Creating IAM Role (%s) inline policy (%s)", roleName, policyName)
+			continue
+		}
+
+		if !failOnExisting {
+			log.Printf("[INFO] Overwriting IAM Role (%s) existing inline policy (%s)", roleName, policyName)
+		}
+		overwritten = append(overwritten, policyName)
+	}
+
+	if failOnExisting && len(overwritten) > 0 {
+		slices.Sort(overwritten)
+		return fmt.Errorf("role already has inline policies %s, and fail_on_existing_inline is set", strings.Join(overwritten, ", "))
+	}
+
+	return nil
+}
+
+// putRoleInlinePolicies adds the specified inline policies to the role.
+// A newly created role may not yet be visible to PutRolePolicy, so NoSuchEntity is retried for a short time,
+// as is ConcurrentModification from the other updates being made to the role.
+// If rollback is true and any policy cannot be added, the policies that were added are removed again.
+func putRoleInlinePolicies(ctx context.Context, conn *iam.IAM, policies []*iam.PutRolePolicyInput, rollback bool, timeout time.Duration) error {
+	var errs *multierror.Error
+	var added []*string
+	for _, policy := range policies {
+		if len(aws.StringValue(policy.PolicyName)) == 0 || len(aws.StringValue(policy.PolicyDocument)) == 0 {
+			continue
+		}
+
+		_, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, roleInlinePolicyNoSuchEntityTimeout, func() (interface{}, error) {
+			return conn.PutRolePolicyWithContext(ctx, policy)
+		}, iam.ErrCodeConcurrentModificationException, iam.ErrCodeNoSuchEntityException)
+
+		if err != nil {
+			newErr := fmt.Errorf("adding inline policy (%s): %w", aws.StringValue(policy.PolicyName), err)
+			errs = multierror.Append(errs, newErr)
+			continue
+		}
+
+		added = append(added, policy.PolicyName)
 	}
 
-	policyToSet, err := verify.PolicyToSet(d.Get("assume_role_policy").(string), assumeRolePolicy)
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", d.Id(), err)
+	if rollback && errs != nil && len(added) > 0 {
+		log.Printf("[DEBUG] Rolling back IAM Role (%s) inline policies: %s", aws.StringValue(policies[0].RoleName), strings.Join(aws.StringValueSlice(added), ", "))
+
+		if err := deleteRoleInlinePolicies(ctx, conn, aws.StringValue(policies[0].RoleName), added, timeout); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("rolling back: %w", err))
+		}
 	}
 
-	d.Set("assume_role_policy", policyToSet)
+	return errs.ErrorOrNil()
+}
 
-	inlinePolicies, err := readRoleInlinePolicies(ctx, aws.StringValue(role.RoleName), meta)
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading inline policies for IAM role %s, error: %s", d.Id(), err)
+// expandRoleDescription returns the description to send to IAM.
+// A description that is only known at apply time can be longer than IAM allows; depending on `description_on_overflow`
+// it is either rejected before calling IAM or truncated to the maximum length.
+func expandRoleDescription(description, onOverflow string) (string, error) {
+	n := utf8.RuneCountInString(description)
+	if n <= roleDescriptionMaxLen {
+		return description, nil
 	}
 
-	var configPoliciesList []*iam.PutRolePolicyInput
-	if v := d.Get("inline_policy").(*schema.Set); v.Len() > 0 {
-		configPoliciesList = expandRoleInlinePolicies(aws.StringValue(role.RoleName), v.List())
+	if onOverflow != roleDescriptionOnOverflowTruncate {
+		return "", fmt.Errorf("description is %d characters, longer than the maximum of %d; set description_on_overflow to %q to truncate it", n, roleDescriptionMaxLen, roleDescriptionOnOverflowTruncate)
 	}
 
-	if !inlinePoliciesEquivalent(inlinePolicies, configPoliciesList) {
-		if err := d.Set("inline_policy", flattenRoleInlinePolicies(inlinePolicies)); err != nil {
-			return sdkdiag.AppendErrorf(diags, "setting inline_policy: %s", err)
-		}
+	log.Printf("[WARN] Truncating IAM Role description from %d to %d characters", n, roleDescriptionMaxLen)
+
+	return string([]rune(description)[:roleDescriptionMaxLen]), nil
+}
+
+// suppressTruncatedRoleDescriptionDiff suppresses the diff between a truncated description in state
+// and the over-length configured description it was truncated from.
+func suppressTruncatedRoleDescriptionDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("description_on_overflow").(string) != roleDescriptionOnOverflowTruncate {
+		return false
 	}
 
-	managedPolicies, err := readRolePolicyAttachments(ctx, conn, aws.StringValue(role.RoleName))
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading managed policies for IAM role %s, error: %s", d.Id(), err)
+	if utf8.RuneCountInString(new) <= roleDescriptionMaxLen {
+		return false
 	}
-	d.Set("managed_policy_arns", managedPolicies)
 
-	setTagsOut(ctx, role.Tags)
+	truncated, _ := expandRoleDescription(new, roleDescriptionOnOverflowTruncate)
 
-	return diags
+	return old == truncated
 }
 
-func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+// expandRoleAssumeRolePolicy returns the normalized trust policy JSON, from the `assume_role_policy` string,
+// the structured `assume_role_policy_document` block or the `trusted_services` set.
+func expandRoleAssumeRolePolicy(d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("assume_role_policy_document"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		return expandRoleAssumeRolePolicyDocument(v.([]interface{})[0].(map[string]interface{}))
+	}
 
-	if d.HasChange("assume_role_policy") {
-		assumeRolePolicy, err := structure.NormalizeJsonString(d.Get("assume_role_policy").(string))
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "assume_role_policy (%s) is invalid JSON: %s", assumeRolePolicy, err)
-		}
+	if v, ok := d.GetOk("trusted_services"); ok && v.(*schema.Set).Len() > 0 {
+		return expandRoleTrustedServicesPolicy(flex.ExpandStringValueSet(v.(*schema.Set)))
+	}
 
-		input := &iam.UpdateAssumeRolePolicyInput{
-			RoleName:       aws.String(d.Id()),
-			PolicyDocument: aws.String(assumeRolePolicy),
-		}
+	assumeRolePolicy, err := structure.NormalizeJsonString(d.Get("assume_role_policy").(string))
+	if err != nil {
+		return "", fmt.Errorf("assume_role_policy (%s) is invalid JSON: %w", assumeRolePolicy, err)
+	}
 
-		_, err = tfresource.RetryWhen(ctx, propagationTimeout,
-			func() (interface{}, error) {
-				return conn.UpdateAssumeRolePolicyWithContext(ctx, input)
-			},
-			func(err error) (bool, error) {
-				if tfawserr.ErrMessageContains(err, iam.ErrCodeMalformedPolicyDocumentException, "Invalid principal in policy") {
-					return true, err
-				}
+	return assumeRolePolicy, nil
+}
 
-				return false, err
-			},
-		)
+func expandRoleAssumeRolePolicyDocument(tfMap map[string]interface{}) (string, error) {
+	doc := &IAMPolicyDoc{
+		Version: tfMap["version"].(string),
+	}
 
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) assume role policy: %s", d.Id(), err)
+	for _, tfMapRaw := range tfMap["statement"].([]interface{}) {
+		tfStmt, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
 		}
-	}
 
-	if d.HasChange("description") {
-		input := &iam.UpdateRoleDescriptionInput{
-			RoleName:    aws.String(d.Id()),
-			Description: aws.String(d.Get("description").(string)),
+		stmt := &IAMPolicyStatement{
+			Effect: tfStmt["effect"].(string),
+			Sid:    tfStmt["sid"].(string),
 		}
 
-		_, err := conn.UpdateRoleDescriptionWithContext(ctx, input)
+		if actions := tfStmt["actions"].(*schema.Set).List(); len(actions) > 0 {
+			stmt.Actions = policyDecodeConfigStringList(actions)
+		}
 
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) description: %s", d.Id(), err)
+		if principals := tfStmt["principals"].(*schema.Set).List(); len(principals) > 0 {
+			var err error
+			stmt.Principals, err = dataSourcePolicyDocumentMakePrincipals(principals, doc.Version)
+			if err != nil {
+				return "", fmt.Errorf("reading assume_role_policy_document principals: %w", err)
+			}
 		}
-	}
 
-	if d.HasChange("max_session_duration") {
-		input := &iam.UpdateRoleInput{
-			RoleName:           aws.String(d.Id()),
-			MaxSessionDuration: aws.Int64(int64(d.Get("max_session_duration").(int))),
+		if conditions := tfStmt["condition"].(*schema.Set).List(); len(conditions) > 0 {
+			var err error
+			stmt.Conditions, err = dataSourcePolicyDocumentMakeConditions(conditions, doc.Version)
+			if err != nil {
+				return "", fmt.Errorf("reading assume_role_policy_document condition: %w", err)
+			}
 		}
 
-		_, err := conn.UpdateRoleWithContext(ctx, input)
+		doc.Statements = append(doc.Statements, stmt)
+	}
 
+	if v, ok := tfMap["session_tag_keys"].(*schema.Set); ok && v.Len() > 0 {
+		stmt, err := expandRoleSessionTagsStatement(doc.Statements, flex.ExpandStringValueSet(v))
 		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) MaxSessionDuration: %s", d.Id(), err)
+			return "", err
 		}
+
+		doc.Statements = append(doc.Statements, stmt)
 	}
 
-	if d.HasChange("permissions_boundary") {
-		permissionsBoundary := d.Get("permissions_boundary").(string)
-		if permissionsBoundary != "" {
-			input := &iam.PutRolePermissionsBoundaryInput{
-				PermissionsBoundary: aws.String(permissionsBoundary),
-				RoleName:            aws.String(d.Id()),
-			}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshaling assume_role_policy_document: %w", err)
+	}
+
+	return string(b), nil
+}
 
-			_, err := conn.PutRolePermissionsBoundaryWithContext(ctx, input)
+// expandRoleSessionTagsStatement returns a statement allowing the principals of the trust policy's
+// Allow statements to pass the specified session tags, and to set them as transitive, when assuming the role.
+func expandRoleSessionTagsStatement(statements []*IAMPolicyStatement, tagKeys []string) (*IAMPolicyStatement, error) {
+	var principals IAMPolicyStatementPrincipalSet
+	seen := make(map[string]struct{})
 
-			if err != nil {
-				return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) permissions boundary: %s", d.Id(), err)
-			}
-		} else {
-			input := &iam.DeleteRolePermissionsBoundaryInput{
-				RoleName: aws.String(d.Id()),
+	for _, stmt := range statements {
+		if stmt.Effect != "Allow" {
+			continue
+		}
+
+		for _, principal := range stmt.Principals {
+			var identifiers []string
+			switch v := principal.Identifiers.(type) {
+			case string:
+				identifiers = []string{v}
+			case []string:
+				identifiers = v
 			}
 
-			_, err := conn.DeleteRolePermissionsBoundaryWithContext(ctx, input)
+			for _, identifier := range identifiers {
+				if _, ok := seen[principal.Type+"/"+identifier]; ok {
+					continue
+				}
+				seen[principal.Type+"/"+identifier] = struct{}{}
 
-			if err != nil {
-				return sdkdiag.AppendErrorf(diags, "deleting IAM Role (%s) permissions boundary: %s", d.Id(), err)
+				principals = append(principals, IAMPolicyStatementPrincipal{
+					Type:        principal.Type,
+					Identifiers: []string{identifier},
+				})
 			}
 		}
 	}
 
-	if d.HasChange("inline_policy") && inlinePoliciesActualDiff(d) {
-		roleName := d.Get("name").(string)
+	if len(principals) == 0 {
+		return nil, errors.New("assume_role_policy_document session_tag_keys requires an Allow statement with principals")
+	}
 
-		o, n := d.GetChange("inline_policy")
+	slices.Sort(tagKeys)
 
-		if o == nil {
-			o = new(schema.Set)
-		}
+	return &IAMPolicyStatement{
+		Effect:     "Allow",
+		Actions:    "sts:TagSession",
+		Principals: principals,
+		Conditions: IAMPolicyStatementConditionSet{
+			{Test: "ForAllValues:StringEquals", Variable: "aws:TagKeys", Values: tagKeys},
+			{Test: "ForAllValues:StringEquals", Variable: "sts:TransitiveTagKeys", Values: tagKeys},
+		},
+	}, nil
+}
 
-		if n == nil {
-			n = new(schema.Set)
-		}
+// expandRoleTrustedServicesPolicy returns a trust policy allowing the specified service principals to assume the role.
+func expandRoleTrustedServicesPolicy(services []string) (string, error) {
+	services = slices.Clone(services)
+	slices.Sort(services)
 
-		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
+	doc := &IAMPolicyDoc{
+		Version: "2012-10-17",
+		Statements: []*IAMPolicyStatement{{
+			Effect:  "Allow",
+			Actions: "sts:AssumeRole",
+			Principals: IAMPolicyStatementPrincipalSet{{
+				Type:        "Service",
+				Identifiers: services,
+			}},
+		}},
+	}
 
-		remove := os.Difference(ns).List()
-		add := ns.Difference(os).List()
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshaling trusted_services policy: %w", err)
+	}
 
-		var policyNames []*string
-		for _, policy := range remove {
-			tfMap, ok := policy.(map[string]interface{})
+	return string(b), nil
+}
 
-			if !ok {
-				continue
-			}
+func flattenRoleAssumeRolePrincipals(apiObjects []IAMPolicyStatementPrincipal) []interface{} {
+	tfList := make([]interface{}, 0, len(apiObjects))
 
-			if v, ok := tfMap["name"].(string); ok && v != "" {
-				policyNames = append(policyNames, aws.String(tfMap["name"].(string)))
-			}
-		}
-		if err := deleteRoleInlinePolicies(ctx, conn, roleName, policyNames); err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s): %s", d.Id(), err)
-		}
+	for _, apiObject := range apiObjects {
+		tfList = append(tfList, map[string]interface{}{
+			"identifiers": apiObject.Identifiers,
+			"type":        apiObject.Type,
+		})
+	}
 
-		policies := expandRoleInlinePolicies(roleName, add)
-		if err := addRoleInlinePolicies(ctx, policies, meta); err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s): %s", d.Id(), err)
-		}
+	return tfList
+}
+
+// splitRoleAssumeRolePolicyStatements removes the statements with the specified Sids from a trust policy.
+// The remaining policy and the removed statements are returned.
+func splitRoleAssumeRolePolicyStatements(policy string, sids []string) (string, []interface{}, error) {
+	var doc map[string]interface{}
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return "", nil, fmt.Errorf("parsing assume role policy: %w", err)
 	}
 
-	if d.HasChange("managed_policy_arns") {
-		roleName := d.Get("name").(string)
+	var statements []interface{}
+	switch v := doc["Statement"].(type) {
+	case []interface{}:
+		statements = v
+	case map[string]interface{}:
+		statements = []interface{}{v}
+	default:
+		return policy, nil, nil
+	}
 
-		o, n := d.GetChange("managed_policy_arns")
-		if o == nil {
-			o = new(schema.Set)
-		}
-		if n == nil {
-			n = new(schema.Set)
+	var kept, removed []interface{}
+	for _, statement := range statements {
+		if m, ok := statement.(map[string]interface{}); ok {
+			if sid, ok := m["Sid"].(string); ok && slices.Contains(sids, sid) {
+				removed = append(removed, statement)
+				continue
+			}
 		}
 
-		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
-		remove := flex.ExpandStringSet(os.Difference(ns))
-		add := flex.ExpandStringSet(ns.Difference(os))
+		kept = append(kept, statement)
+	}
 
-		if err := deleteRolePolicyAttachments(ctx, conn, roleName, remove); err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s): %s", d.Id(), err)
-		}
+	if len(removed) == 0 {
+		return policy, nil, nil
+	}
 
-		if err := addRoleManagedPolicies(ctx, roleName, add, meta); err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s): %s", d.Id(), err)
-		}
+	doc["Statement"] = kept
+	if kept == nil {
+		doc["Statement"] = make([]interface{}, 0)
 	}
 
-	if d.HasChange("tags_all") {
-		o, n := d.GetChange("tags_all")
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshaling assume role policy: %w", err)
+	}
 
-		err := roleUpdateTags(ctx, conn, d.Id(), o, n)
+	return string(b), removed, nil
+}
 
-		// Some partitions (e.g. ISO) may not support tagging.
-		if errs.IsUnsupportedOperationInPartitionError(conn.PartitionID, err) {
-			return append(diags, resourceRoleRead(ctx, d, meta)...)
-		}
+// addRoleAssumeRolePolicyIgnoredStatements appends the role's current statements with the specified Sids
+// to a new trust policy, replacing any configured statements with the same Sids.
+func addRoleAssumeRolePolicyIgnoredStatements(ctx context.Context, conn *iam.IAM, roleName, policy string, sids []string) (string, error) {
+	role, err := FindRoleByName(ctx, conn, roleName)
 
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating tags for IAM Role (%s): %s", d.Id(), err)
-		}
+	if err != nil {
+		return "", fmt.Errorf("reading IAM Role (%s): %w", roleName, err)
 	}
 
-	return append(diags, resourceRoleRead(ctx, d, meta)...)
+	current, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return "", fmt.Errorf("parsing IAM Role (%s) assume role policy: %w", roleName, err)
+	}
+
+	return mergeRoleAssumeRolePolicyStatements(policy, current, sids)
 }
 
-func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+// mergeRoleAssumeRolePolicyStatements returns the new trust policy with the statements having the specified Sids
+// taken from the current trust policy.
+func mergeRoleAssumeRolePolicyStatements(policy, current string, sids []string) (string, error) {
+	_, ignored, err := splitRoleAssumeRolePolicyStatements(current, sids)
+	if err != nil {
+		return "", err
+	}
 
-	hasInline := false
-	if v, ok := d.GetOk("inline_policy"); ok && v.(*schema.Set).Len() > 0 {
-		hasInline = true
+	if len(ignored) == 0 {
+		return policy, nil
 	}
 
-	hasManaged := false
-	if v, ok := d.GetOk("managed_policy_arns"); ok && v.(*schema.Set).Len() > 0 {
-		hasManaged = true
+	policy, _, err = splitRoleAssumeRolePolicyStatements(policy, sids)
+	if err != nil {
+		return "", err
 	}
 
-	err := DeleteRole(ctx, conn, d.Id(), d.Get("force_detach_policies").(bool), hasInline, hasManaged)
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return "", fmt.Errorf("parsing assume role policy: %w", err)
+	}
 
-	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-		return diags
+	var statements []interface{}
+	switch v := doc["Statement"].(type) {
+	case []interface{}:
+		statements = v
+	case map[string]interface{}:
+		statements = []interface{}{v}
 	}
 
+	doc["Statement"] = append(statements, ignored...)
+
+	b, err := json.Marshal(doc)
 	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "deleting IAM Role (%s): %s", d.Id(), err)
+		return "", fmt.Errorf("marshaling assume role policy: %w", err)
 	}
 
-	return diags
+	return string(b), nil
 }
 
-func DeleteRole(ctx context.Context, conn *iam.IAM, roleName string, forceDetach, hasInline, hasManaged bool) error {
-	if err := deleteRoleInstanceProfiles(ctx, conn, roleName); err != nil {
-		return fmt.Errorf("unable to detach instance profiles: %w", err)
+// roleReadSkipsPolicies returns whether Read can skip listing the role's policies.
+// This is only the case for an existing role whose configuration is available (i.e. not during
+// refresh or import) and configures neither `inline_policy` blocks nor `managed_policy_arns` or `managed_policy_names`.
+func roleReadSkipsPolicies(d *schema.ResourceData) bool {
+	if d.IsNewResource() {
+		return false
 	}
 
-	if forceDetach || hasManaged {
-		managedPolicies, err := readRolePolicyAttachments(ctx, conn, roleName)
-		if err != nil {
-			return err
-		}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
 
-		if err := deleteRolePolicyAttachments(ctx, conn, roleName, managedPolicies); err != nil {
-			return fmt.Errorf("unable to detach policies: %w", err)
-		}
+	inlinePolicy := rawConfig.GetAttr("inline_policy")
+	if !inlinePolicy.IsNull() && (!inlinePolicy.IsKnown() || inlinePolicy.LengthInt() > 0) {
+		return false
 	}
 
-	if forceDetach || hasInline {
-		inlinePolicies, err := readRolePolicyNames(ctx, conn, roleName)
-		if err != nil {
-			return err
-		}
+	if !rawConfig.GetAttr("inline_policies").IsNull() {
+		return false
+	}
 
-		if err := deleteRoleInlinePolicies(ctx, conn, roleName, inlinePolicies); err != nil {
-			return fmt.Errorf("unable to delete inline policies: %w", err)
-		}
+	return rawConfig.GetAttr("managed_policy_arns").IsNull() && rawConfig.GetAttr("managed_policy_names").IsNull()
+}
+
+func filterRoleInlinePolicies(apiObjects, managed []*iam.PutRolePolicyInput) []*iam.PutRolePolicyInput {
+	names := make(map[string]struct{})
+	for _, apiObject := range managed {
+		names[aws.StringValue(apiObject.PolicyName)] = struct{}{}
 	}
 
-	deleteRoleInput := &iam.DeleteRoleInput{
-		RoleName: aws.String(roleName),
+	var filtered []*iam.PutRolePolicyInput
+
+	for _, apiObject := range apiObjects {
+		if _, ok := names[aws.StringValue(apiObject.PolicyName)]; ok {
+			filtered = append(filtered, apiObject)
+		}
 	}
-	err := retry.RetryContext(ctx, propagationTimeout, func() *retry.RetryError {
-		_, err := conn.DeleteRoleWithContext(ctx, deleteRoleInput)
-		if err != nil {
-			if tfawserr.ErrCodeEquals(err, iam.ErrCodeDeleteConflictException) {
-				return retry.RetryableError(err)
-			}
 
-			return retry.NonRetryableError(err)
+	return filtered
+}
+
+func filterRoleManagedPolicies(policyARNs []*string, managed *schema.Set) []*string {
+	var filtered []*string
+
+	for _, policyARN := range policyARNs {
+		if managed.Contains(aws.StringValue(policyARN)) {
+			filtered = append(filtered, policyARN)
 		}
-		return nil
-	})
-	if tfresource.TimedOut(err) {
-		_, err = conn.DeleteRoleWithContext(ctx, deleteRoleInput)
 	}
 
-	return err
+	return filtered
 }
 
-func deleteRoleInstanceProfiles(ctx context.Context, conn *iam.IAM, roleName string) error {
-	resp, err := conn.ListInstanceProfilesForRoleWithContext(ctx, &iam.ListInstanceProfilesForRoleInput{
-		RoleName: aws.String(roleName),
-	})
-	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-		return nil
+// updateRoleManagedPolicies attaches the added managed policies before detaching the removed ones,
+// so that the role never loses permissions that both the old and new sets of policies grant.
+// This matters for roles used by the automation that applies the change.
+// Nothing is detached if any policy could not be attached, unless it was because the role
+// reached its managed policy quota. Those policies are attached once the removed ones are detached.
+func updateRoleManagedPolicies(ctx context.Context, conn *iam.IAM, roleName string, add, remove []*string, timeout time.Duration) error {
+	limitExceeded, err := attachRoleManagedPoliciesUpToQuota(ctx, conn, roleName, aws.StringValueSlice(add), timeout)
+	if err != nil || len(remove) == 0 {
+		return roleManagedPolicyAttachError(err, roleName, len(add), limitExceeded)
 	}
-	if err != nil {
+
+	if err := deleteRolePolicyAttachments(ctx, conn, roleName, remove, timeout); err != nil {
 		return err
 	}
 
-	// Loop and remove this Role from any Profiles
-	for _, i := range resp.InstanceProfiles {
-		input := &iam.RemoveRoleFromInstanceProfileInput{
-			InstanceProfileName: i.InstanceProfileName,
-			RoleName:            aws.String(roleName),
-		}
-
-		_, err := conn.RemoveRoleFromInstanceProfileWithContext(ctx, input)
-		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-			continue
-		}
-		if err != nil {
-			return err
-		}
+	if len(limitExceeded) == 0 {
+		return nil
 	}
 
-	return nil
+	// E.g. a policy swapped for another on a role at its quota.
+	return attachRoleManagedPolicies(ctx, conn, roleName, limitExceeded, timeout)
 }
 
-func retryCreateRole(ctx context.Context, conn *iam.IAM, input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
-	outputRaw, err := tfresource.RetryWhen(ctx, propagationTimeout,
-		func() (interface{}, error) {
-			return conn.CreateRoleWithContext(ctx, input)
-		},
-		func(err error) (bool, error) {
-			if tfawserr.ErrMessageContains(err, iam.ErrCodeMalformedPolicyDocumentException, "Invalid principal in policy") {
-				return true, err
-			}
+func addRoleManagedPolicies(ctx context.Context, roleName string, policies []*string, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
 
-			return false, err
-		},
-	)
+	return attachRoleManagedPolicies(ctx, conn, roleName, aws.StringValueSlice(policies), rolePropagationTimeout(meta))
+}
 
-	if err != nil {
-		return nil, err
+// roleBoundaryCappedPolicyNames are AWS managed policies whose permissions are broad enough that,
+// with a permissions boundary set, the boundary rather than the policy decides what the role can do.
+var roleBoundaryCappedPolicyNames = []string{
+	"AdministratorAccess",
+	"IAMFullAccess",
+	"PowerUserAccess",
+}
+
+// appendRoleBoundaryCappedPolicyWarnings warns about each broad AWS managed policy attached to a role
+// that has a permissions boundary. The attachment is allowed; only its effect is capped.
+func appendRoleBoundaryCappedPolicyWarnings(diags diag.Diagnostics, roleName, permissionsBoundary string, policyARNs []string) diag.Diagnostics {
+	if permissionsBoundary == "" {
+		return diags
 	}
 
-	output, ok := outputRaw.(*iam.CreateRoleOutput)
-	if !ok || output == nil || aws.StringValue(output.Role.RoleName) == "" {
-		return nil, fmt.Errorf("create IAM role (%s) returned an empty result", aws.StringValue(input.RoleName))
+	for _, policyARN := range policyARNs {
+		parsedARN, err := arn.Parse(policyARN)
+		if err != nil || parsedARN.Service != iam.ServiceName || parsedARN.AccountID != "aws" {
+			continue
+		}
+
+		if policyName := parsedARN.Resource[strings.LastIndex(parsedARN.Resource, "/")+1:]; slices.Contains(roleBoundaryCappedPolicyNames, policyName) {
+			diags = sdkdiag.AppendWarningf(diags, "IAM Role (%s) has managed policy (%s) attached and permissions boundary (%s) set; the role's effective permissions are capped by the boundary", roleName, policyARN, permissionsBoundary)
+		}
 	}
 
-	return output, err
+	return diags
 }
 
-func FindRoleByName(ctx context.Context, conn *iam.IAM, name string) (*iam.Role, error) {
-	input := &iam.GetRoleInput{
-		RoleName: aws.String(name),
+// appendRoleExternalManagedPolicyWarnings warns about managed policies attached to a role that are not in its
+// configured `managed_policy_arns`. With exclusive management the next apply detaches them, and if they
+// are attached by another resource, such as aws_iam_role_policy_attachment, the two keep undoing each other.
+// No warning is given unless the configuration is available and sets `managed_policy_arns`, so it is not given during refresh or import.
+func appendRoleExternalManagedPolicyWarnings(diags diag.Diagnostics, d *schema.ResourceData, policyARNs []string) diag.Diagnostics {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return diags
+	}
+
+	v := rawConfig.GetAttr("managed_policy_arns")
+	if v.IsNull() || !v.IsWhollyKnown() {
+		return diags
 	}
 
-	output, err := conn.GetRoleWithContext(ctx, input)
+	var configuredPolicyARNs []string
+	for _, v := range v.AsValueSlice() {
+		configuredPolicyARNs = append(configuredPolicyARNs, v.AsString())
+	}
 
-	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-		return nil, &retry.NotFoundError{
-			LastError:   err,
-			LastRequest: input,
+	var external []string
+	for _, policyARN := range policyARNs {
+		if !slices.Contains(configuredPolicyARNs, policyARN) {
+			external = append(external, policyARN)
 		}
 	}
 
-	if err != nil {
-		return nil, err
+	if len(external) == 0 {
+		return diags
 	}
 
-	if output == nil || output.Role == nil {
-		return nil, tfresource.NewEmptyResultError(input)
-	}
+	slices.Sort(external)
 
-	return output.Role, nil
+	return sdkdiag.AppendWarningf(diags, "IAM Role (%s) has managed policies attached outside of this resource: %s. The next apply detaches them, and any resource that attaches them, such as aws_iam_role_policy_attachment, attaches them again. Manage the role's attachments in one place, or set managed_policy_arns_exclusive to false", d.Id(), strings.Join(external, ", "))
 }
 
-func readRolePolicyAttachments(ctx context.Context, conn *iam.IAM, roleName string) ([]*string, error) {
-	managedPolicies := make([]*string, 0)
-	input := &iam.ListAttachedRolePoliciesInput{
-		RoleName: aws.String(roleName),
+// roleImportedManagedPolicyDetachments returns the managed policies to detach from the role. The first update after
+// an import detaches none, because the configuration may not yet list every policy that was attached before the import,
+// and warns that the next apply detaches them instead.
+func roleImportedManagedPolicyDetachments(diags diag.Diagnostics, roleName string, importPending bool, policyARNs []*string) ([]*string, diag.Diagnostics) {
+	if !importPending || len(policyARNs) == 0 {
+		return policyARNs, diags
 	}
 
-	err := conn.ListAttachedRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
-		for _, v := range page.AttachedPolicies {
-			managedPolicies = append(managedPolicies, v.PolicyArn)
-		}
-		return !lastPage
-	})
-	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-		return nil, err
-	}
+	detachments := aws.StringValueSlice(policyARNs)
+	slices.Sort(detachments)
 
-	return managedPolicies, nil
+	return nil, sdkdiag.AppendWarningf(diags, "IAM Role (%s) was imported with managed policies that are not in managed_policy_arns: %s. As this is the first apply since the import, they were not detached. Add them to managed_policy_arns to keep them, or apply again to detach them", roleName, strings.Join(detachments, ", "))
 }
 
-func deleteRolePolicyAttachments(ctx context.Context, conn *iam.IAM, roleName string, managedPolicies []*string) error {
-	for _, arn := range managedPolicies {
-		input := &iam.DetachRolePolicyInput{
-			PolicyArn: arn,
-			RoleName:  aws.String(roleName),
-		}
+// attachRoleManagedPolicies attaches the specified managed policies to the role.
+// Policies that were attached before an error are left attached.
+// Policies that could not be attached because the role's managed policy quota was reached are reported together.
+func attachRoleManagedPolicies(ctx context.Context, conn *iam.IAM, roleName string, policyARNs []string, timeout time.Duration) error {
+	limitExceeded, err := attachRoleManagedPoliciesUpToQuota(ctx, conn, roleName, policyARNs, timeout)
 
-		_, err := conn.DetachRolePolicyWithContext(ctx, input)
-		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-			continue
-		}
-		if err != nil {
-			return fmt.Errorf("detaching managed policy (%s): %w", aws.StringValue(arn), err)
+	return roleManagedPolicyAttachError(err, roleName, len(policyARNs), limitExceeded)
+}
+
+// attachRoleManagedPoliciesUpToQuota attaches the specified managed policies to the role, returning those
+// that could not be attached because the role's managed policy quota was reached separately from any other error.
+func attachRoleManagedPoliciesUpToQuota(ctx context.Context, conn *iam.IAM, roleName string, policyARNs []string, timeout time.Duration) ([]string, error) {
+	var mu sync.Mutex
+	var limitExceeded []string
+
+	err := forEachRoleManagedPolicy(policyARNs, func(policyARN string) error {
+		if err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+			return nil, attachPolicyToRole(ctx, conn, roleName, policyARN)
+		}); err != nil {
+			if tfawserr.ErrCodeEquals(err, iam.ErrCodeLimitExceededException) {
+				mu.Lock()
+				limitExceeded = append(limitExceeded, policyARN)
+				mu.Unlock()
+
+				return nil
+			}
+
+			return fmt.Errorf("attaching managed policy (%s): %w", policyARN, err)
 		}
-	}
 
-	return nil
+		return nil
+	})
+
+	slices.Sort(limitExceeded)
+
+	return limitExceeded, err
 }
 
-func readRolePolicyNames(ctx context.Context, conn *iam.IAM, roleName string) ([]*string, error) {
-	inlinePolicies := make([]*string, 0)
-	input := &iam.ListRolePoliciesInput{
-		RoleName: aws.String(roleName),
+// roleManagedPolicyAttachError combines err with an error listing the policies that could not be attached
+// because the role's managed policy quota was reached.
+func roleManagedPolicyAttachError(err error, roleName string, attempted int, limitExceeded []string) error {
+	if len(limitExceeded) == 0 {
+		return err
 	}
 
-	err := conn.ListRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
-		inlinePolicies = append(inlinePolicies, page.PolicyNames...)
-		return !lastPage
-	})
+	limitErr := fmt.Errorf("attaching %d managed policies to IAM Role (%s) exceeded the managed policies per role quota, %d not attached: %s. "+
+		"Request an increase of the \"Managed policies per role\" quota in Service Quotas, or consolidate the policies into fewer managed or inline policies",
+		attempted, roleName, len(limitExceeded), strings.Join(limitExceeded, ", "))
 
-	if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-		return nil, err
+	if err == nil {
+		return limitErr
 	}
 
-	return inlinePolicies, nil
+	return multierror.Append(err, limitErr)
 }
 
-func deleteRoleInlinePolicies(ctx context.Context, conn *iam.IAM, roleName string, policyNames []*string) error {
-	for _, name := range policyNames {
-		if len(aws.StringValue(name)) == 0 {
-			continue
-		}
+// forEachRoleManagedPolicy calls f for each of the specified policy ARNs, running at most
+// roleManagedPolicyConcurrency calls concurrently. A failure does not stop the remaining calls
+// and all errors are aggregated.
+func forEachRoleManagedPolicy(policyARNs []string, f func(string) error) error {
+	var g multierror.Group
+	sem := make(chan struct{}, roleManagedPolicyConcurrency)
 
-		input := &iam.DeleteRolePolicyInput{
-			PolicyName: name,
-			RoleName:   aws.String(roleName),
-		}
+	for _, policyARN := range policyARNs {
+		policyARN := policyARN
 
-		_, err := conn.DeleteRolePolicyWithContext(ctx, input)
-		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-			return nil
-		}
-		if err != nil {
-			return fmt.Errorf("removing inline policy (%s): %w", aws.StringValue(name), err)
-		}
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			return f(policyARN)
+		})
 	}
 
-	return nil
+	return g.Wait().ErrorOrNil()
 }
 
-func flattenRoleInlinePolicy(apiObject *iam.PutRolePolicyInput) map[string]interface{} {
-	if apiObject == nil {
-		return nil
+// refreshRoleManagedPoliciesExceedingBoundary sets `managed_policies_exceeding_boundary` if `compute_boundary_analysis`
+// is set and the role has a permissions boundary, and clears it otherwise. The analysis is advisory, so if it fails
+// a warning is returned and the attribute is left empty.
+func refreshRoleManagedPoliciesExceedingBoundary(ctx context.Context, conn *iam.IAM, d *schema.ResourceData, role *iam.Role) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.Set("managed_policies_exceeding_boundary", nil)
+
+	if !d.Get("compute_boundary_analysis").(bool) || role.PermissionsBoundary == nil {
+		return diags
 	}
 
-	tfMap := map[string]interface{}{}
+	// Every attached policy is analyzed, including those attached outside this resource.
+	policyARNs, err := readRolePolicyAttachments(ctx, conn, aws.StringValue(role.RoleName))
+	if err != nil {
+		return sdkdiag.AppendWarningf(diags, "analyzing IAM Role (%s) managed policies against its permissions boundary: %s", d.Id(), err)
+	}
 
-	tfMap["name"] = aws.StringValue(apiObject.PolicyName)
-	tfMap["policy"] = aws.StringValue(apiObject.PolicyDocument)
+	exceeding, err := roleManagedPoliciesExceedingBoundary(ctx, conn, aws.StringValue(role.PermissionsBoundary.PermissionsBoundaryArn), aws.StringValueSlice(policyARNs))
+	if err != nil {
+		return sdkdiag.AppendWarningf(diags, "analyzing IAM Role (%s) managed policies against its permissions boundary: %s", d.Id(), err)
+	}
 
-	return tfMap
+	d.Set("managed_policies_exceeding_boundary", exceeding)
+
+	return diags
 }
 
-func flattenRoleInlinePolicies(apiObjects []*iam.PutRolePolicyInput) []interface{} {
-	if len(apiObjects) == 0 {
+// rolePermissionsBoundaryUpdate returns the update that changes the role's permissions boundary from old to new.
+// It returns nil if both ARNs identify the same policy, so that a difference in how the ARN is written does not
+// cause a redundant API call.
+func rolePermissionsBoundaryUpdate(ctx context.Context, conn *iam.IAM, roleName, old, new string, timeout time.Duration) func() error {
+	if suppressEquivalentPolicyARN("permissions_boundary", old, new, nil) {
+		log.Printf("[DEBUG] IAM Role (%s) permissions boundary is unchanged, not updating it", roleName)
 		return nil
 	}
 
-	var tfList []interface{}
-
-	for _, apiObject := range apiObjects {
-		if apiObject == nil {
-			continue
+	if new == "" {
+		input := &iam.DeleteRolePermissionsBoundaryInput{
+			RoleName: aws.String(roleName),
 		}
 
-		tfList = append(tfList, flattenRoleInlinePolicy(apiObject))
-	}
-
-	return tfList
-}
+		return func() error {
+			if err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+				return conn.DeleteRolePermissionsBoundaryWithContext(ctx, input)
+			}); err != nil {
+				return fmt.Errorf("deleting permissions boundary: %w", err)
+			}
 
-func expandRoleInlinePolicy(roleName string, tfMap map[string]interface{}) *iam.PutRolePolicyInput {
-	if tfMap == nil {
-		return nil
+			return nil
+		}
 	}
 
-	apiObject := &iam.PutRolePolicyInput{}
+	input := &iam.PutRolePermissionsBoundaryInput{
+		PermissionsBoundary: aws.String(new),
+		RoleName:            aws.String(roleName),
+	}
 
-	namePolicy := false
+	return func() error {
+		if err := retryRoleConcurrentModification(ctx, timeout, func() (interface{}, error) {
+			return conn.PutRolePermissionsBoundaryWithContext(ctx, input)
+		}); err != nil {
+			return fmt.Errorf("updating permissions boundary: %w", err)
+		}
 
-	if v, ok := tfMap["name"].(string); ok && v != "" {
-		apiObject.PolicyName = aws.String(v)
-		namePolicy = true
+		return nil
 	}
+}
 
-	if v, ok := tfMap["policy"].(string); ok && v != "" {
-		apiObject.PolicyDocument = aws.String(v)
-		namePolicy = true
+// readRolePermissionsBoundaryDocument returns the decoded document of the default version of the role's
+// permissions boundary, which may be a customer or an AWS managed policy, or "" if the role has no boundary.
+func readRolePermissionsBoundaryDocument(ctx context.Context, conn *iam.IAM, role *iam.Role) (string, error) {
+	if role.PermissionsBoundary == nil {
+		return "", nil
 	}
 
-	if namePolicy {
-		apiObject.RoleName = aws.String(roleName)
+	boundaryARN := aws.StringValue(role.PermissionsBoundary.PermissionsBoundaryArn)
+	document, err := findPolicyDocumentByARN(ctx, conn, boundaryARN)
+	if err != nil {
+		return "", fmt.Errorf("reading policy (%s): %w", boundaryARN, err)
 	}
 
-	return apiObject
+	return document, nil
 }
 
-func expandRoleInlinePolicies(roleName string, tfList []interface{}) []*iam.PutRolePolicyInput {
-	if len(tfList) == 0 {
-		return nil
+// roleManagedPoliciesExceedingBoundary returns, sorted, the ARNs of the managed policies that allow actions the
+// permissions boundary does not, as determined by policyActionsExceedingBoundary. The default version of the
+// boundary and of each policy is read from IAM.
+func roleManagedPoliciesExceedingBoundary(ctx context.Context, conn *iam.IAM, boundaryARN string, policyARNs []string) ([]string, error) {
+	boundary, err := findPolicyDocumentByARN(ctx, conn, boundaryARN)
+	if err != nil {
+		return nil, fmt.Errorf("reading permissions boundary (%s): %w", boundaryARN, err)
 	}
 
-	var apiObjects []*iam.PutRolePolicyInput
-
-	for _, tfMapRaw := range tfList {
-		tfMap, ok := tfMapRaw.(map[string]interface{})
+	var mu sync.Mutex
+	exceeding := make([]string, 0)
 
-		if !ok {
-			continue
+	err = forEachRoleManagedPolicy(policyARNs, func(policyARN string) error {
+		policy, err := findPolicyDocumentByARN(ctx, conn, policyARN)
+		if err != nil {
+			return fmt.Errorf("reading managed policy (%s): %w", policyARN, err)
 		}
 
-		apiObject := expandRoleInlinePolicy(roleName, tfMap)
+		actions, err := policyActionsExceedingBoundary(policy, boundary)
+		if err != nil {
+			return fmt.Errorf("managed policy (%s): %w", policyARN, err)
+		}
 
-		if apiObject == nil {
-			continue
+		if len(actions) > 0 {
+			mu.Lock()
+			exceeding = append(exceeding, policyARN)
+			mu.Unlock()
 		}
 
-		apiObjects = append(apiObjects, apiObject)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return apiObjects
+	slices.Sort(exceeding)
+
+	return exceeding, nil
 }
 
-func addRoleInlinePolicies(ctx context.Context, policies []*iam.PutRolePolicyInput, meta interface{}) error {
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+// refreshRoleInlinePolicies reads the role's inline policies into `inline_policy` and `inline_policy_count`.
+// If reading them fails and `partial_read_on_error` is set, a warning is returned and the values in state are kept,
+// so that one failed call, for example after throttling, does not fail the whole refresh.
+func refreshRoleInlinePolicies(ctx context.Context, conn *iam.IAM, d *schema.ResourceData, roleName string) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	var errs *multierror.Error
-	for _, policy := range policies {
-		if len(aws.StringValue(policy.PolicyName)) == 0 || len(aws.StringValue(policy.PolicyDocument)) == 0 {
-			continue
+	inlinePolicies, err := readRoleInlinePolicies(ctx, conn, roleName)
+	if err != nil {
+		if d.Get("partial_read_on_error").(bool) {
+			log.Printf("[WARN] reading IAM Role (%s) inline policies, keeping inline_policy from state: %s", d.Id(), err)
+			return sdkdiag.AppendWarningf(diags, "reading IAM Role (%s) inline policies: %s; inline_policy was not refreshed", d.Id(), err)
 		}
 
-		if _, err := conn.PutRolePolicyWithContext(ctx, policy); err != nil {
-			newErr := fmt.Errorf("adding inline policy (%s): %w", aws.StringValue(policy.PolicyName), err)
-			errs = multierror.Append(errs, newErr)
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", d.Id(), err)
+	}
+
+	// inline_policies is authoritative, so once it is in use every inline policy is tracked in it.
+	if v := d.Get("inline_policies").(map[string]interface{}); len(v) > 0 {
+		prior := v
+		if d.Get("normalize_inline_policies").(bool) {
+			// Store the normalized documents read from IAM rather than keeping equivalent documents from the configuration.
+			prior = nil
+		}
+
+		inlinePoliciesMap, err := flattenRoleInlinePoliciesMap(inlinePolicies, prior)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", d.Id(), err)
+		}
+
+		if err := d.Set("inline_policies", inlinePoliciesMap); err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting inline_policies: %s", err)
 		}
 	}
 
-	return errs.ErrorOrNil()
-}
+	var configPoliciesList []*iam.PutRolePolicyInput
+	if v := d.Get("inline_policy").(*schema.Set); v.Len() > 0 {
+		configPoliciesList = expandRoleInlinePolicies(roleName, v.List())
+	}
 
-func addRoleManagedPolicies(ctx context.Context, roleName string, policies []*string, meta interface{}) error {
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+	if !d.Get("inline_policy_exclusive").(bool) {
+		// Only track the inline policies this resource manages so that policies added out of band
+		// (e.g. by aws_iam_role_policy) are not deleted on the next apply.
+		inlinePolicies = filterRoleInlinePolicies(inlinePolicies, configPoliciesList)
+	}
 
-	var errs *multierror.Error
-	for _, arn := range policies {
-		if err := attachPolicyToRole(ctx, conn, roleName, aws.StringValue(arn)); err != nil {
-			newErr := fmt.Errorf("attaching managed policy (%s): %w", aws.StringValue(arn), err)
-			errs = multierror.Append(errs, newErr)
+	d.Set("inline_policy_count", len(inlinePolicies))
+
+	if !inlinePoliciesEquivalent(inlinePolicies, configPoliciesList) {
+		if err := d.Set("inline_policy", flattenRoleInlinePolicies(inlinePolicies)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting inline_policy: %s", err)
 		}
 	}
 
-	return errs.ErrorOrNil()
+	return diags
 }
 
-func readRoleInlinePolicies(ctx context.Context, roleName string, meta interface{}) ([]*iam.PutRolePolicyInput, error) {
-	conn := meta.(*conns.AWSClient).IAMConn(ctx)
-
+// readRoleInlinePolicies reads the role's inline policies, in the order they are listed by IAM.
+// Policy documents are read concurrently, at most roleInlinePolicyReadConcurrency at a time,
+// and a throttled read is retried with backoff.
+func readRoleInlinePolicies(ctx context.Context, conn *iam.IAM, roleName string) ([]*iam.PutRolePolicyInput, error) {
 	policyNames, err := readRolePolicyNames(ctx, conn, roleName)
 	if err != nil {
 		return nil, err
 	}
 
-	var apiObjects []*iam.PutRolePolicyInput
-	for _, policyName := range policyNames {
-		policyResp, err := conn.GetRolePolicyWithContext(ctx, &iam.GetRolePolicyInput{
-			RoleName:   aws.String(roleName),
-			PolicyName: policyName,
-		})
-		if err != nil {
-			return nil, err
-		}
+	var g multierror.Group
+	sem := make(chan struct{}, roleInlinePolicyReadConcurrency)
+	apiObjects := make([]*iam.PutRolePolicyInput, len(policyNames))
 
-		policy, err := url.QueryUnescape(*policyResp.PolicyDocument)
-		if err != nil {
-			return nil, err
-		}
+	for i, policyName := range policyNames {
+		i, policyName := i, policyName
 
-		p, err := verify.LegacyPolicyNormalize(policy)
-		if err != nil {
-			return nil, fmt.Errorf("policy (%s) is invalid JSON: %w", p, err)
-		}
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-		apiObject := &iam.PutRolePolicyInput{
-			RoleName:       aws.String(roleName),
-			PolicyDocument: aws.String(p),
-			PolicyName:     policyName,
-		}
+			outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, roleInlinePolicyThrottlingTimeout, func() (interface{}, error) {
+				return conn.GetRolePolicyWithContext(ctx, &iam.GetRolePolicyInput{
+					RoleName:   aws.String(roleName),
+					PolicyName: policyName,
+				})
+			}, "Throttling")
+			if err != nil {
+				return fmt.Errorf("reading inline policy (%s): %w", aws.StringValue(policyName), err)
+			}
 
-		apiObjects = append(apiObjects, apiObject)
+			policy, err := url.QueryUnescape(aws.StringValue(outputRaw.(*iam.GetRolePolicyOutput).PolicyDocument))
+			if err != nil {
+				return err
+			}
+
+			p, err := verify.LegacyPolicyNormalize(policy)
+			if err != nil {
+				return fmt.Errorf("policy (%s) is invalid JSON: %w", p, err)
+			}
+
+			apiObjects[i] = &iam.PutRolePolicyInput{
+				RoleName:       aws.String(roleName),
+				PolicyDocument: aws.String(p),
+				PolicyName:     policyName,
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait().ErrorOrNil(); err != nil {
+		return nil, err
 	}
 
 	return apiObjects, nil
@@ -942,3 +3359,96 @@ func inlinePoliciesEquivalent(readPolicies, configPolicies []*iam.PutRolePolicyI
 
 	return matches == len(readPolicies)
 }
+
+// roleAutoTags returns the tag that `auto_tag_name` adds to a role: a Name tag set to the role name.
+// No tag is added if tags, the role's other tags, already has a Name tag or if the provider's
+// `ignore_tags` ignores the Name key.
+func roleAutoTags(ignoreConfig *tftags.IgnoreConfig, roleName string, tags tftags.KeyValueTags) tftags.KeyValueTags {
+	if _, ok := tags[roleAutoTagNameKey]; ok {
+		return nil
+	}
+
+	return tftags.KeyValueTags{roleAutoTagNameKey: &tftags.TagData{Value: aws.String(roleName)}}.IgnoreConfig(ignoreConfig)
+}
+
+// removeRoleAutoTags removes the Name tag added by `auto_tag_name` from tags read from IAM,
+// unless a Name tag is configured and so is part of tagsAll.
+func removeRoleAutoTags(tags []*iam.Tag, roleName string, tagsAll map[string]interface{}) []*iam.Tag {
+	if _, ok := tagsAll[roleAutoTagNameKey]; ok {
+		return tags
+	}
+
+	var output []*iam.Tag
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == roleAutoTagNameKey && aws.StringValue(tag.Value) == roleName {
+			continue
+		}
+
+		output = append(output, tag)
+	}
+
+	return output
+}
+
+// splitRoleExternalTags splits tags read from IAM into the tags managed by Terraform, whose keys
+// are in tagsAll, and the tags added outside Terraform.
+func splitRoleExternalTags(tags []*iam.Tag, tagsAll map[string]interface{}) ([]*iam.Tag, []*iam.Tag) {
+	var managed, external []*iam.Tag
+
+	for _, tag := range tags {
+		if _, ok := tagsAll[aws.StringValue(tag.Key)]; ok {
+			managed = append(managed, tag)
+		} else {
+			external = append(external, tag)
+		}
+	}
+
+	return managed, external
+}
+
+func trimRoleTags(tags []*iam.Tag) []*iam.Tag {
+	var trimmed []*iam.Tag
+
+	for _, tag := range tags {
+		trimmed = append(trimmed, &iam.Tag{
+			Key:   aws.String(strings.TrimSpace(aws.StringValue(tag.Key))),
+			Value: aws.String(strings.TrimSpace(aws.StringValue(tag.Value))),
+		})
+	}
+
+	return trimmed
+}
+
+func trimRoleTagsMap(tags map[string]interface{}) map[string]interface{} {
+	trimmed := make(map[string]interface{}, len(tags))
+
+	for k, v := range tags {
+		trimmed[strings.TrimSpace(k)] = strings.TrimSpace(v.(string))
+	}
+
+	return trimmed
+}
+
+// untrimRoleTags maps tags read from IAM, which were trimmed on write, back to their
+// configured (untrimmed) form so that whitespace differences do not show as drift.
+func untrimRoleTags(tags []*iam.Tag, configured map[string]interface{}) []*iam.Tag {
+	type keyValue struct {
+		key, value string
+	}
+
+	untrimmed := make(map[keyValue]keyValue, len(configured))
+	for k, v := range configured {
+		untrimmed[keyValue{strings.TrimSpace(k), strings.TrimSpace(v.(string))}] = keyValue{k, v.(string)}
+	}
+
+	var output []*iam.Tag
+	for _, tag := range tags {
+		if v, ok := untrimmed[keyValue{aws.StringValue(tag.Key), aws.StringValue(tag.Value)}]; ok {
+			output = append(output, &iam.Tag{Key: aws.String(v.key), Value: aws.String(v.value)})
+		} else {
+			output = append(output, tag)
+		}
+	}
+
+	return output
+}
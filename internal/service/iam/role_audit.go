@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+)
+
+const (
+	roleAuditOperationCreate = "create"
+	roleAuditOperationUpdate = "update"
+	roleAuditOperationDelete = "delete"
+
+	// roleAuditLogProviderLog is the provider `iam_role_audit_log` value that selects the provider log.
+	// Plugin standard output is discarded by Terraform, so records are written via the log package instead.
+	roleAuditLogProviderLog = "-"
+)
+
+var (
+	// roleAuditLogMutex serializes writes to the audit log across concurrently applied roles.
+	roleAuditLogMutex sync.Mutex
+
+	// roleAttributeNames holds the sorted attribute names of the aws_iam_role schema.
+	roleAttributeNames     []string
+	roleAttributeNamesOnce sync.Once
+)
+
+// roleAuditRecord is a single line of the IAM role audit log.
+type roleAuditRecord struct {
+	Time              string   `json:"time"`
+	Operation         string   `json:"operation"`
+	RoleName          string   `json:"role_name"`
+	ChangedAttributes []string `json:"changed_attributes"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// roleAudited wraps an IAM role mutation handler so that, when the provider's
+// `iam_role_audit_log` setting is configured, a JSON record of the mutation is emitted.
+func roleAudited(operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, ok := meta.(*conns.AWSClient)
+		if !ok || client.IAMRoleAuditLog == "" {
+			return f(ctx, d, meta)
+		}
+
+		// Capture the changed attributes and role name before the handler refreshes state.
+		var changed []string
+		if operation != roleAuditOperationDelete {
+			changed = roleChangedAttributes(d)
+		}
+		roleName := d.Id()
+
+		diags := f(ctx, d, meta)
+
+		if roleName == "" {
+			roleName = d.Id()
+		}
+
+		record := roleAuditRecord{
+			Time:              time.Now().UTC().Format(time.RFC3339),
+			Operation:         operation,
+			RoleName:          roleName,
+			ChangedAttributes: changed,
+		}
+
+		if diags.HasError() {
+			record.Error = sdkdiag.DiagnosticsError(diags).Error()
+		}
+
+		if err := writeRoleAuditRecord(client.IAMRoleAuditLog, record); err != nil {
+			diags = sdkdiag.AppendWarningf(diags, "writing IAM Role (%s) audit record: %s", roleName, err)
+		}
+
+		return diags
+	}
+}
+
+func roleChangedAttributes(d *schema.ResourceData) []string {
+	roleAttributeNamesOnce.Do(func() {
+		for k := range ResourceRole().Schema {
+			roleAttributeNames = append(roleAttributeNames, k)
+		}
+
+		sort.Strings(roleAttributeNames)
+	})
+
+	changed := make([]string, 0)
+
+	for _, k := range roleAttributeNames {
+		if d.HasChange(k) {
+			changed = append(changed, k)
+		}
+	}
+
+	return changed
+}
+
+func writeRoleAuditRecord(path string, record roleAuditRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if path == roleAuditLogProviderLog {
+		log.Printf("[INFO] IAM Role audit: %s", b)
+
+		return nil
+	}
+
+	roleAuditLogMutex.Lock()
+	defer roleAuditLogMutex.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintln(f, string(b))
+
+	return err
+}
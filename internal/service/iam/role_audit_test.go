@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"golang.org/x/exp/slices"
+)
+
+func TestRoleAudited(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	meta := &conns.AWSClient{IAMRoleAuditLog: path}
+
+	handler := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		d.SetId("test-role")
+		return nil
+	}
+
+	for _, operation := range []string{roleAuditOperationCreate, roleAuditOperationUpdate, roleAuditOperationDelete} {
+		d := schema.TestResourceDataRaw(t, ResourceRole().Schema, map[string]interface{}{
+			"assume_role_policy": `{"Version":"2012-10-17","Statement":[]}`,
+			"name":               "test-role",
+		})
+
+		if diags := roleAudited(operation, handler)(ctx, d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening audit log: %s", err)
+	}
+	defer f.Close()
+
+	var records []roleAuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record roleAuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("decoding audit record: %s", err)
+		}
+		records = append(records, record)
+	}
+
+	if got, want := len(records), 3; got != want {
+		t.Fatalf("got %d audit records, want %d", got, want)
+	}
+
+	for i, want := range []string{roleAuditOperationCreate, roleAuditOperationUpdate, roleAuditOperationDelete} {
+		record := records[i]
+
+		if record.Operation != want {
+			t.Errorf("record %d: got operation %q, want %q", i, record.Operation, want)
+		}
+
+		if record.RoleName != "test-role" {
+			t.Errorf("record %d: got role name %q, want %q", i, record.RoleName, "test-role")
+		}
+
+		if hasName := slices.Contains(record.ChangedAttributes, "name"); hasName != (want != roleAuditOperationDelete) {
+			t.Errorf("record %d: unexpected changed attributes %v", i, record.ChangedAttributes)
+		}
+	}
+}
+
+func TestRoleAudited_disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+
+	handler := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		called = true
+		return nil
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceRole().Schema, map[string]interface{}{})
+
+	if diags := roleAudited(roleAuditOperationCreate, handler)(ctx, d, &conns.AWSClient{}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !called {
+		t.Error("expected wrapped handler to be called")
+	}
+}
@@ -5,7 +5,6 @@ package iam
 
 import (
 	"context"
-	"net/url"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -39,10 +38,19 @@ func DataSourceRole() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"managed_policy_arns": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"max_session_duration": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"max_session_duration_is_maximum": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -107,6 +115,7 @@ func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.Set("description", output.Role.Description)
 	d.Set("max_session_duration", output.Role.MaxSessionDuration)
+	d.Set("max_session_duration_is_maximum", aws.Int64Value(output.Role.MaxSessionDuration) == roleMaxSessionDurationMax)
 	d.Set("name", output.Role.RoleName)
 	d.Set("path", output.Role.Path)
 	d.Set("permissions_boundary", "")
@@ -115,7 +124,7 @@ func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 	d.Set("unique_id", output.Role.RoleId)
 
-	assumRolePolicy, err := url.QueryUnescape(aws.StringValue(output.Role.AssumeRolePolicyDocument))
+	assumRolePolicy, err := decodeRoleAssumeRolePolicy(aws.StringValue(output.Role.AssumeRolePolicyDocument))
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "parsing assume role policy document: %s", err)
 	}
@@ -123,6 +132,12 @@ func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interf
 		return sdkdiag.AppendErrorf(diags, "setting assume_role_policy: %s", err)
 	}
 
+	managedPolicies, err := readRolePolicyAttachments(ctx, conn, name)
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) managed policies: %s", name, err)
+	}
+	d.Set("managed_policy_arns", aws.StringValueSlice(managedPolicies))
+
 	tags := KeyValueTags(ctx, output.Role.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
 
 	//lintignore:AWSR002
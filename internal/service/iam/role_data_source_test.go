@@ -32,6 +32,7 @@ func TestAccIAMRoleDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceName, "create_date", resourceName, "create_date"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "description", resourceName, "description"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "max_session_duration", resourceName, "max_session_duration"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "max_session_duration_is_maximum", resourceName, "max_session_duration_is_maximum"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "path", resourceName, "path"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "unique_id", resourceName, "unique_id"),
@@ -73,6 +74,28 @@ func TestAccIAMRoleDataSource_tags(t *testing.T) {
 	})
 }
 
+func TestAccIAMRoleDataSource_managedPolicyARNs(t *testing.T) {
+	ctx := acctest.Context(t)
+	roleName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleDataSourceConfig_managedPolicyARNs(roleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "managed_policy_arns.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "managed_policy_arns.*", "aws_iam_policy.test.0", "arn"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "managed_policy_arns.*", "aws_iam_policy.test.1", "arn"),
+				),
+			},
+		},
+	})
+}
+
 const testAccRoleDataSourceConfig_AssumeRolePolicy_ExpectedJSON = `{
   "Version": "2012-10-17",
   "Statement": [
@@ -134,3 +157,42 @@ data "aws_iam_role" "test" {
 }
 `, roleName))
 }
+
+func testAccRoleDataSourceConfig_managedPolicyARNs(roleName string) string {
+	return acctest.ConfigCompose(
+		testAccRoleDataSourceConfigBase(),
+		fmt.Sprintf(`
+resource "aws_iam_policy" "test" {
+  count = 2
+
+  name = "%[1]s-${count.index}"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["ec2:Describe*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_role" "test" {
+  name               = %[1]q
+  assume_role_policy = data.aws_iam_policy_document.test.json
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  count = 2
+
+  role       = aws_iam_role.test.name
+  policy_arn = aws_iam_policy.test[count.index].arn
+}
+
+data "aws_iam_role" "test" {
+  name = aws_iam_role.test.name
+
+  depends_on = [aws_iam_role_policy_attachment.test]
+}
+`, roleName))
+}
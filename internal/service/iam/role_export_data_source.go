@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+)
+
+const (
+	roleExportSchemaVersion = "1"
+)
+
+// roleExport is the documented schema of the aws_iam_role_export JSON artifact.
+type roleExport struct {
+	SchemaVersion       string                   `json:"schema_version"`
+	Name                string                   `json:"name"`
+	ARN                 string                   `json:"arn"`
+	Path                string                   `json:"path"`
+	Description         string                   `json:"description"`
+	CreateDate          string                   `json:"create_date"`
+	MaxSessionDuration  int64                    `json:"max_session_duration"`
+	PermissionsBoundary string                   `json:"permissions_boundary"`
+	AssumeRolePolicy    json.RawMessage          `json:"assume_role_policy"`
+	InlinePolicies      []roleExportInlinePolicy `json:"inline_policies"`
+	ManagedPolicyARNs   []string                 `json:"managed_policy_arns"`
+	Tags                map[string]string        `json:"tags"`
+}
+
+type roleExportInlinePolicy struct {
+	Name   string          `json:"name"`
+	Policy json.RawMessage `json:"policy"`
+}
+
+// @SDKDataSource("aws_iam_role_export")
+func DataSourceRoleExport() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceRoleExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceRoleExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
+
+	name := d.Get("name").(string)
+
+	role, err := FindRoleByName(ctx, conn, name)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", name, err)
+	}
+
+	assumeRolePolicy, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "parsing IAM Role (%s) assume role policy document: %s", name, err)
+	}
+
+	inlinePolicies, err := readRoleInlinePolicies(ctx, conn, name)
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", name, err)
+	}
+
+	managedPolicies, err := readRolePolicyAttachments(ctx, conn, name)
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) managed policies: %s", name, err)
+	}
+
+	export := roleExport{
+		SchemaVersion:      roleExportSchemaVersion,
+		Name:               aws.StringValue(role.RoleName),
+		ARN:                aws.StringValue(role.Arn),
+		Path:               aws.StringValue(role.Path),
+		Description:        aws.StringValue(role.Description),
+		MaxSessionDuration: aws.Int64Value(role.MaxSessionDuration),
+		AssumeRolePolicy:   json.RawMessage(assumeRolePolicy),
+		InlinePolicies:     make([]roleExportInlinePolicy, 0, len(inlinePolicies)),
+		ManagedPolicyARNs:  aws.StringValueSlice(managedPolicies),
+		Tags:               KeyValueTags(ctx, role.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig).Map(),
+	}
+
+	if role.CreateDate != nil {
+		export.CreateDate = role.CreateDate.Format(time.RFC3339)
+	}
+
+	if role.PermissionsBoundary != nil {
+		export.PermissionsBoundary = aws.StringValue(role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+
+	for _, policy := range inlinePolicies {
+		export.InlinePolicies = append(export.InlinePolicies, roleExportInlinePolicy{
+			Name:   aws.StringValue(policy.PolicyName),
+			Policy: json.RawMessage(aws.StringValue(policy.PolicyDocument)),
+		})
+	}
+
+	// Sort for a stable artifact that can be diffed between accounts.
+	sort.Slice(export.InlinePolicies, func(i, j int) bool {
+		return export.InlinePolicies[i].Name < export.InlinePolicies[j].Name
+	})
+	sort.Strings(export.ManagedPolicyARNs)
+
+	b, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "marshaling IAM Role (%s) export: %s", name, err)
+	}
+
+	d.SetId(aws.StringValue(role.RoleName))
+	d.Set("json", string(b))
+
+	return diags
+}
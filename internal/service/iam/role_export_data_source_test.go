@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccIAMRoleExportDataSource_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_iam_role_export.test"
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleExportDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "name"),
+					testAccCheckRoleExportRoundTrips(dataSourceName, resourceName),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckRoleExportRoundTrips decodes the exported JSON and compares its key attributes with the role resource.
+func testAccCheckRoleExportRoundTrips(dataSourceName, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[dataSourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", dataSourceName)
+		}
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		var export struct {
+			SchemaVersion       string          `json:"schema_version"`
+			Name                string          `json:"name"`
+			ARN                 string          `json:"arn"`
+			Path                string          `json:"path"`
+			Description         string          `json:"description"`
+			MaxSessionDuration  int64           `json:"max_session_duration"`
+			PermissionsBoundary string          `json:"permissions_boundary"`
+			AssumeRolePolicy    json.RawMessage `json:"assume_role_policy"`
+			InlinePolicies      []struct {
+				Name   string          `json:"name"`
+				Policy json.RawMessage `json:"policy"`
+			} `json:"inline_policies"`
+			ManagedPolicyARNs []string          `json:"managed_policy_arns"`
+			Tags              map[string]string `json:"tags"`
+		}
+
+		if err := json.Unmarshal([]byte(ds.Primary.Attributes["json"]), &export); err != nil {
+			return fmt.Errorf("decoding %s json: %w", dataSourceName, err)
+		}
+
+		attrs := rs.Primary.Attributes
+
+		for k, v := range map[string]string{
+			"schema_version":       export.SchemaVersion,
+			"name":                 export.Name,
+			"arn":                  export.ARN,
+			"path":                 export.Path,
+			"description":          export.Description,
+			"max_session_duration": fmt.Sprint(export.MaxSessionDuration),
+			"permissions_boundary": export.PermissionsBoundary,
+		} {
+			want := attrs[k]
+			if k == "schema_version" {
+				want = "1"
+			}
+
+			if v != want {
+				return fmt.Errorf("exported %s = %q, want %q", k, v, want)
+			}
+		}
+
+		if len(export.AssumeRolePolicy) == 0 {
+			return fmt.Errorf("exported assume_role_policy is empty")
+		}
+
+		if got, want := len(export.InlinePolicies), 1; got != want {
+			return fmt.Errorf("exported %d inline policies, want %d", got, want)
+		}
+
+		if got, want := export.InlinePolicies[0].Name, attrs["inline_policy.0.name"]; got != want {
+			return fmt.Errorf("exported inline policy name = %q, want %q", got, want)
+		}
+
+		if got, want := len(export.ManagedPolicyARNs), 1; got != want {
+			return fmt.Errorf("exported %d managed policy ARNs, want %d", got, want)
+		}
+
+		if got, want := export.ManagedPolicyARNs[0], attrs["managed_policy_arns.0"]; got != want {
+			return fmt.Errorf("exported managed policy ARN = %q, want %q", got, want)
+		}
+
+		if got, want := export.Tags["Name"], attrs["tags.Name"]; got != want {
+			return fmt.Errorf("exported Name tag = %q, want %q", got, want)
+		}
+
+		return nil
+	}
+}
+
+func testAccRoleExportDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["ec2:Describe*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_role" "test" {
+  name                 = %[1]q
+  path                 = "/test/"
+  description          = "export test"
+  max_session_duration = 7200
+  managed_policy_arns  = [aws_iam_policy.test.arn]
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["s3:ListAllMyBuckets"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+data "aws_iam_role_export" "test" {
+  name = aws_iam_role.test.name
+}
+`, rName)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// @SDKDataSource("aws_iam_role_inline_policies")
+func DataSourceRoleInlinePolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceRoleInlinePoliciesRead,
+
+		Schema: map[string]*schema.Schema{
+			"inline_policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceRoleInlinePoliciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	roleName := d.Get("role_name").(string)
+
+	// Listing the inline policies of a missing role is not an error, so check for the role first to catch typos.
+	role, err := FindRoleByName(ctx, conn, roleName)
+
+	if tfresource.NotFound(err) {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: IAM Role not found, check that role_name is correct", roleName)
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", roleName, err)
+	}
+
+	inlinePolicies, err := readRoleInlinePolicies(ctx, conn, aws.StringValue(role.RoleName))
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) inline policies: %s", roleName, err)
+	}
+
+	d.SetId(aws.StringValue(role.RoleName))
+	if err := d.Set("inline_policies", flattenRoleInlinePolicies(inlinePolicies)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting inline_policies: %s", err)
+	}
+
+	return diags
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccIAMRoleInlinePoliciesDataSource_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_iam_role_inline_policies.test"
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleInlinePoliciesDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "inline_policies.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "inline_policies.0.name", rName),
+					acctest.CheckResourceAttrEquivalentJSON(dataSourceName, "inline_policies.0.policy", `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Action": "ec2:Describe*",
+    "Effect": "Allow",
+    "Resource": "*"
+  }]
+}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRoleInlinePoliciesDataSource_nonExistent(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleInlinePoliciesDataSourceConfig_nonExistent(rName),
+				ExpectError: regexp.MustCompile(`IAM Role not found`),
+			},
+		},
+	})
+}
+
+func testAccRoleInlinePoliciesDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["ec2:Describe*"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+
+data "aws_iam_role_inline_policies" "test" {
+  role_name = aws_iam_role.test.name
+}
+`, rName)
+}
+
+func testAccRoleInlinePoliciesDataSourceConfig_nonExistent(rName string) string {
+	return fmt.Sprintf(`
+data "aws_iam_role_inline_policies" "test" {
+  role_name = %[1]q
+}
+`, rName)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+// @SDKResource("aws_iam_role_policy_attachments_exclusive")
+func ResourceRolePolicyAttachmentsExclusive() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceRolePolicyAttachmentsExclusiveCreate,
+		ReadWithoutTimeout:   resourceRolePolicyAttachmentsExclusiveRead,
+		UpdateWithoutTimeout: resourceRolePolicyAttachmentsExclusiveUpdate,
+		DeleteWithoutTimeout: resourceRolePolicyAttachmentsExclusiveDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_arns": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: verify.ValidARN,
+				},
+			},
+			"role_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validRoleName(roleNameMaxLen),
+			},
+		},
+	}
+}
+
+func resourceRolePolicyAttachmentsExclusiveCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	roleName := d.Get("role_name").(string)
+
+	if err := syncRolePolicyAttachments(ctx, roleName, flex.ExpandStringValueSet(d.Get("policy_arns").(*schema.Set)), meta); err != nil {
+		return sdkdiag.AppendErrorf(diags, "creating IAM Role (%s) exclusive policy attachments: %s", roleName, err)
+	}
+
+	d.SetId(roleName)
+
+	return append(diags, resourceRolePolicyAttachmentsExclusiveRead(ctx, d, meta)...)
+}
+
+func resourceRolePolicyAttachmentsExclusiveRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	_, err := FindRoleByName(ctx, conn, d.Id())
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] IAM Role (%s) not found, removing exclusive policy attachments from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", d.Id(), err)
+	}
+
+	policyARNs, err := readRolePolicyAttachments(ctx, conn, d.Id())
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) policy attachments: %s", d.Id(), err)
+	}
+
+	d.Set("policy_arns", aws.StringValueSlice(policyARNs))
+	d.Set("role_name", d.Id())
+
+	return diags
+}
+
+func resourceRolePolicyAttachmentsExclusiveUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.HasChange("policy_arns") {
+		if err := syncRolePolicyAttachments(ctx, d.Id(), flex.ExpandStringValueSet(d.Get("policy_arns").(*schema.Set)), meta); err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating IAM Role (%s) exclusive policy attachments: %s", d.Id(), err)
+		}
+	}
+
+	return append(diags, resourceRolePolicyAttachmentsExclusiveRead(ctx, d, meta)...)
+}
+
+func resourceRolePolicyAttachmentsExclusiveDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Attachments are left in place; Terraform simply stops enforcing exclusivity.
+	log.Printf("[DEBUG] Removing IAM Role (%s) exclusive policy attachments from state", d.Id())
+
+	return diags
+}
+
+// syncRolePolicyAttachments attaches and detaches managed policies so that exactly the specified policies are attached to the role.
+func syncRolePolicyAttachments(ctx context.Context, roleName string, policyARNs []string, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	current, err := readRolePolicyAttachments(ctx, conn, roleName)
+
+	if err != nil {
+		return err
+	}
+
+	add, remove := diffRolePolicyAttachments(aws.StringValueSlice(current), policyARNs)
+
+	if err := deleteRolePolicyAttachments(ctx, conn, roleName, aws.StringSlice(remove), rolePropagationTimeout(meta)); err != nil {
+		return err
+	}
+
+	return addRoleManagedPolicies(ctx, roleName, aws.StringSlice(add), meta)
+}
+
+// diffRolePolicyAttachments returns the policy ARNs to attach and to detach to go from the current to the desired attachments.
+func diffRolePolicyAttachments(current, desired []string) ([]string, []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		currentSet[v] = struct{}{}
+	}
+
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, v := range desired {
+		desiredSet[v] = struct{}{}
+	}
+
+	var add, remove []string
+
+	for _, v := range desired {
+		if _, ok := currentSet[v]; !ok {
+			add = append(add, v)
+		}
+	}
+
+	for _, v := range current {
+		if _, ok := desiredSet[v]; !ok {
+			remove = append(remove, v)
+		}
+	}
+
+	return add, remove
+}
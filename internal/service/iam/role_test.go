@@ -5,9 +5,14 @@ package iam_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -15,7 +20,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	tfiam "github.com/hashicorp/terraform-provider-aws/internal/service/iam"
@@ -38,8 +45,21 @@ func TestAccIAMRole_basic(t *testing.T) {
 				Config: testAccRoleConfig_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttrSet(resourceName, "assume_role_policy_hash"),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_principals.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_principals.0.type", "Service"),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_principals.0.identifiers.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "assume_role_principals.0.identifiers.*", fmt.Sprintf("ec2.%s", acctest.PartitionDNSSuffix())),
 					resource.TestCheckResourceAttr(resourceName, "path", "/"),
 					resource.TestCheckResourceAttrSet(resourceName, "create_date"),
+					testAccCheckRoleCreateDateUnix(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_names.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "role_last_used.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "role_last_used.0.last_used_date", ""),
+					resource.TestCheckResourceAttr(resourceName, "role_last_used.0.region", ""),
+					resource.TestCheckResourceAttr(resourceName, "service_linked", "false"),
 				),
 			},
 			{
@@ -51,7 +71,7 @@ func TestAccIAMRole_basic(t *testing.T) {
 	})
 }
 
-func TestAccIAMRole_description(t *testing.T) {
+func TestAccIAMRole_importARN(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -64,11 +84,10 @@ func TestAccIAMRole_description(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_description(rName),
+				Config: testAccRoleConfig_path(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "path", "/"),
-					resource.TestCheckResourceAttr(resourceName, "description", "This 1s a D3scr!pti0n with weird content: &@90ë\"'{«¡Çø}"),
+					resource.TestCheckResourceAttr(resourceName, "path", "/tf-testing/nested/"),
 				),
 			},
 			{
@@ -77,29 +96,24 @@ func TestAccIAMRole_description(t *testing.T) {
 				ImportStateVerify: true,
 			},
 			{
-				Config: testAccRoleConfig_updatedDescription(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "path", "/"),
-					resource.TestCheckResourceAttr(resourceName, "description", "This 1s an Upd@ted D3scr!pti0n with weird content: &90ë\"'{«¡Çø}"),
-				),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccRoleARNImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
 			},
 			{
-				Config: testAccRoleConfig_basic(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-					resource.TestCheckResourceAttrSet(resourceName, "create_date"),
-					resource.TestCheckResourceAttr(resourceName, "description", ""),
-				),
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "arn:aws:s3:::" + rName, // lintignore:AWSAT005
+				ExpectError:   regexp.MustCompile(`unexpected format for IAM Role ARN`),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_nameGenerated(t *testing.T) {
+func TestAccIAMRole_serviceLinkedPath(t *testing.T) {
 	ctx := acctest.Context(t)
-	var conf iam.Role
-	resourceName := "aws_iam_role.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
@@ -108,25 +122,17 @@ func TestAccIAMRole_nameGenerated(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_nameGenerated(),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-					acctest.CheckResourceAttrNameGenerated(resourceName, "name"),
-					resource.TestCheckResourceAttr(resourceName, "name_prefix", "terraform-"),
-				),
-			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config:      testAccRoleConfig_pathValue(rName, "/aws-service-role/ec2.amazonaws.com/"),
+				ExpectError: regexp.MustCompile(`path \(/aws-service-role/ec2.amazonaws.com/\) is reserved for service-linked roles.*aws_iam_service_linked_role`),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_namePrefix(t *testing.T) {
+func TestAccIAMRole_importARNStalePath(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -136,23 +142,25 @@ func TestAccIAMRole_namePrefix(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_namePrefix(acctest.ResourcePrefix),
+				Config: testAccRoleConfig_path(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
-					acctest.CheckResourceAttrNameFromPrefix(resourceName, "name", acctest.ResourcePrefix),
-					resource.TestCheckResourceAttr(resourceName, "name_prefix", acctest.ResourcePrefix),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				// The same role name at another path must not match the existing role.
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return strings.Replace(aws.StringValue(conf.Arn), "/tf-testing/nested/", "/tf-testing/other/", 1), nil
+				},
+				ExpectError: regexp.MustCompile(`has path /tf-testing/nested/, not the path /tf-testing/other/`),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_testNameChange(t *testing.T) {
+func TestAccIAMRole_pathRecreatedOutOfBand(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -165,29 +173,26 @@ func TestAccIAMRole_testNameChange(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_pre(rName),
+				Config: testAccRoleConfig_path(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
+					// Recreate the role with the same name at another path; the next plan replaces it.
+					testAccCheckRoleRecreateWithPath(ctx, &conf, "/tf-testing/other/"),
 				),
+				ExpectNonEmptyPlan: true,
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-			},
-			{
-				Config: testAccRoleConfig_post(rName),
+				Config: testAccRoleConfig_path(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "path", "/tf-testing/nested/"),
 				),
 			},
 		},
 	})
 }
 
-// https://github.com/hashicorp/terraform-provider-aws/issues/23288
-// https://github.com/hashicorp/terraform-provider-aws/issues/28833
-func TestAccIAMRole_diffs(t *testing.T) {
+func TestAccIAMRole_pathMissingTrailingSlash(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -200,133 +205,24 @@ func TestAccIAMRole_diffs(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, ""),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Config: testAccRoleConfig_pathValue(rName, "/tf-testing"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "path", "/tf-testing/"),
 				),
 			},
 			{
-				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				// Adding the trailing slash to the configuration does not replace the role.
+				Config:   testAccRoleConfig_pathValue(rName, "/tf-testing/"),
 				PlanOnly: true,
 			},
 		},
 	})
 }
 
-// https://github.com/hashicorp/terraform-provider-aws/issues/28835
-func TestAccIAMRole_diffsCondition(t *testing.T) {
+func TestAccIAMRole_assumeRolePolicyWaitForPropagation(t *testing.T) {
 	ctx := acctest.Context(t)
-	var conf iam.Role
+	var role iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
@@ -337,42 +233,28 @@ func TestAccIAMRole_diffsCondition(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_diffsCondition(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_diffsCondition(rName),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffsCondition(rName),
+				Config: testAccRoleConfig_assumeRolePolicyWaitForPropagation(rName, "ec2"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_wait_for_propagation", "true"),
 				),
 			},
 			{
-				Config:   testAccRoleConfig_diffsCondition(rName),
-				PlanOnly: true,
-			},
-			{
-				Config: testAccRoleConfig_diffsCondition(rName),
+				Config: testAccRoleConfig_assumeRolePolicyWaitForPropagation(rName, "lambda"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`lambda`)),
 				),
 			},
-			{
-				Config:   testAccRoleConfig_diffsCondition(rName),
-				PlanOnly: true,
-			},
 		},
 	})
 }
 
-func TestAccIAMRole_badJSON(t *testing.T) {
+func TestAccIAMRole_assumeRolePolicyReformatted(t *testing.T) {
 	ctx := acctest.Context(t)
+	var role iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
@@ -381,17 +263,24 @@ func TestAccIAMRole_badJSON(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config:      testAccRoleConfig_badJSON(rName),
-				ExpectError: regexp.MustCompile(`.*contains an invalid JSON:.*`),
+				Config: testAccRoleConfig_assumeRolePolicyHeredoc(rName, "  "),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`^\{"Statement":\[\{"Action":"sts:AssumeRole"`)),
+				),
+			},
+			{
+				// Only whitespace changed, so the normalized value in state is unchanged.
+				Config:   testAccRoleConfig_assumeRolePolicyHeredoc(rName, "\t\t\t\t"),
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_disappears(t *testing.T) {
+func TestAccIAMRole_descriptionOnOverflow(t *testing.T) {
 	ctx := acctest.Context(t)
 	var role iam.Role
-
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
@@ -402,18 +291,26 @@ func TestAccIAMRole_disappears(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_basic(rName),
+				Config:      testAccRoleConfig_descriptionOnOverflow(rName, "error"),
+				ExpectError: regexp.MustCompile(`description is \d+ characters, longer than the maximum of 1000`),
+			},
+			{
+				Config: testAccRoleConfig_descriptionOnOverflow(rName, "truncate"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
-					acctest.CheckResourceDisappears(ctx, acctest.Provider, tfiam.ResourceRole(), resourceName),
+					resource.TestMatchResourceAttr(resourceName, "description", regexp.MustCompile(`^.{1000}$`)),
+					resource.TestCheckResourceAttr(resourceName, "description_on_overflow", "truncate"),
 				),
-				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:   testAccRoleConfig_descriptionOnOverflow(rName, "truncate"),
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_policiesForceDetach(t *testing.T) {
+func TestAccIAMRole_description(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -426,23 +323,39 @@ func TestAccIAMRole_policiesForceDetach(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_forceDetachPolicies(rName),
+				Config: testAccRoleConfig_description(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
-					testAccAddRolePolicy(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "path", "/"),
+					resource.TestCheckResourceAttr(resourceName, "description", "This 1s a D3scr!pti0n with weird content: &@90ë\"'{«¡Çø}"),
 				),
 			},
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"force_detach_policies"},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleConfig_updatedDescription(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "path", "/"),
+					resource.TestCheckResourceAttr(resourceName, "description", "This 1s an Upd@ted D3scr!pti0n with weird content: &90ë\"'{«¡Çø}"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttrSet(resourceName, "create_date"),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_maxSessionDuration(t *testing.T) {
+func TestAccIAMRole_descriptionCleared(t *testing.T) {
 	ctx := acctest.Context(t)
 	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
@@ -455,134 +368,79 @@ func TestAccIAMRole_maxSessionDuration(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config:      testAccRoleConfig_maxSessionDuration(rName, 3599),
-				ExpectError: regexp.MustCompile(`expected max_session_duration to be in the range`),
-			},
-			{
-				Config:      testAccRoleConfig_maxSessionDuration(rName, 43201),
-				ExpectError: regexp.MustCompile(`expected max_session_duration to be in the range`),
-			},
-			{
-				Config: testAccRoleConfig_maxSessionDuration(rName, 3700),
+				Config: testAccRoleConfig_descriptionValue(rName, "before"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "3700"),
+					resource.TestCheckResourceAttr(resourceName, "description", "before"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-			},
-			{
-				Config: testAccRoleConfig_maxSessionDuration(rName, 3701),
+				Config: testAccRoleConfig_descriptionValue(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &conf),
-					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "3701"),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					func(s *terraform.State) error {
+						if v := aws.StringValue(conf.Description); v != "" {
+							return fmt.Errorf("IAM Role (%s) description is %q, want it cleared", rName, v)
+						}
+
+						return nil
+					},
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config:   testAccRoleConfig_descriptionValue(rName, ""),
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_permissionsBoundary(t *testing.T) {
+func TestAccIAMRole_nameGenerated(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
-
-	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	var conf iam.Role
 	resourceName := "aws_iam_role.test"
 
-	permissionsBoundary1 := fmt.Sprintf("arn:%s:iam::aws:policy/AdministratorAccess", acctest.Partition())
-	permissionsBoundary2 := fmt.Sprintf("arn:%s:iam::aws:policy/ReadOnlyAccess", acctest.Partition())
-
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
 		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
 		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckUserDestroy(ctx),
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
-			// Test creation
-			{
-				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary1),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary1),
-					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary1),
-				),
-			},
-			// Test update
 			{
-				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary2),
+				Config: testAccRoleConfig_nameGenerated(),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary2),
-					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary2),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					acctest.CheckResourceAttrNameGenerated(resourceName, "name"),
+					resource.TestCheckResourceAttr(resourceName, "name_prefix", "terraform-"),
 				),
 			},
-			// Test import
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
 				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"force_destroy",
-				},
-			},
-			// Test removal
-			{
-				Config: testAccRoleConfig_basic(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", ""),
-					testAccCheckRolePermissionsBoundary(&role, ""),
-				),
-			},
-			// Test addition
-			{
-				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary1),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary1),
-					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary1),
-				),
-			},
-			// Test empty value
-			{
-				Config: testAccRoleConfig_permissionsBoundary(rName, ""),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", ""),
-					testAccCheckRolePermissionsBoundary(&role, ""),
-				),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_tags(t *testing.T) {
+func TestAccIAMRole_namePrefix(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
-	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	var conf iam.Role
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
 		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
 		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckUserDestroy(ctx),
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_tags(rName),
+				Config: testAccRoleConfig_namePrefix(acctest.ResourcePrefix),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
-					resource.TestCheckResourceAttr(resourceName, "tags.tag1", "test-value1"),
-					resource.TestCheckResourceAttr(resourceName, "tags.tag2", "test-value2"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					acctest.CheckResourceAttrNameFromPrefix(resourceName, "name", acctest.ResourcePrefix),
+					resource.TestCheckResourceAttr(resourceName, "name_prefix", acctest.ResourcePrefix),
 				),
 			},
 			{
@@ -590,25 +448,14 @@ func TestAccIAMRole_tags(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
-			{
-				Config: testAccRoleConfig_tagsUpdate(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
-					resource.TestCheckResourceAttr(resourceName, "tags.tag2", "test-value"),
-				),
-			},
 		},
 	})
 }
 
-func TestAccIAMRole_InlinePolicy_basic(t *testing.T) {
+func TestAccIAMRole_effectiveName(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName3 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -618,46 +465,35 @@ func TestAccIAMRole_InlinePolicy_basic(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyInline(rName, policyName1),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "name", rName),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
-				),
-			},
-			{
-				Config: testAccRoleConfig_policyInlineUpdate(rName, policyName2, policyName3),
+				Config: testAccRoleConfig_basic(rName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						testAccRolePlanCheckKnownValue(resourceName, "effective_name", rName),
+					},
+				},
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "effective_name", rName),
 				),
 			},
 			{
-				Config: testAccRoleConfig_policyInlineUpdateDown(rName, policyName3),
+				Config: testAccRoleConfig_namePrefix(acctest.ResourcePrefix),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("effective_name")),
+					},
+				},
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttrPair(resourceName, "effective_name", resourceName, "name"),
 				),
 			},
-			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"inline_policy.0.policy"},
-			},
 		},
 	})
 }
 
-// Reference: https://github.com/hashicorp/terraform-provider-aws/issues/19444
-func TestAccIAMRole_InlinePolicy_ignoreOrder(t *testing.T) {
+func TestAccIAMRole_nameEmpty(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
-	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
@@ -666,34 +502,17 @@ func TestAccIAMRole_InlinePolicy_ignoreOrder(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyInlineActionOrder(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "name", rName),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
-				),
-			},
-			{
-				Config:   testAccRoleConfig_policyInlineActionOrder(rName),
-				PlanOnly: true,
-			},
-			{
-				Config:   testAccRoleConfig_policyInlineActionNewOrder(rName),
-				PlanOnly: true,
-			},
-			{
-				Config:             testAccRoleConfig_policyInlineActionOrderActualDiff(rName),
-				PlanOnly:           true,
-				ExpectNonEmptyPlan: true,
+				// An empty name is rejected at plan time by the name length validation.
+				Config:      testAccRoleConfig_nameEmpty(),
+				ExpectError: regexp.MustCompile(`expected length of name to be in the range \(1 - 64\)`),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_InlinePolicy_empty(t *testing.T) {
+func TestAccIAMRole_testNameChange(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
@@ -704,22 +523,32 @@ func TestAccIAMRole_InlinePolicy_empty(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Config: testAccRoleConfig_pre(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleConfig_post(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
 		},
 	})
 }
 
-func TestAccIAMRole_ManagedPolicy_basic(t *testing.T) {
+// https://github.com/hashicorp/terraform-provider-aws/issues/23288
+// https://github.com/hashicorp/terraform-provider-aws/issues/28833
+func TestAccIAMRole_diffs(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName3 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -729,77 +558,134 @@ func TestAccIAMRole_ManagedPolicy_basic(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyManaged(rName, policyName1),
+				Config: testAccRoleConfig_diffs(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "name", rName),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
 			{
-				Config: testAccRoleConfig_policyManagedUpdate(rName, policyName1, policyName2, policyName3),
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "2"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
 			{
-				Config: testAccRoleConfig_policyManagedUpdateDown(rName, policyName1, policyName2, policyName3),
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
 			},
-		},
-	})
-}
-
-// TestAccIAMRole_PolicyOutOfBandRemovalAddedBack_managedNonEmpty: if a policy is detached
-// out of band, it should be reattached.
-func TestAccIAMRole_ManagedPolicy_outOfBandRemovalAddedBack(t *testing.T) {
-	ctx := acctest.Context(t)
-	var role iam.Role
-	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	resourceName := "aws_iam_role.test"
-
-	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
-		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
-		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
-		CheckDestroy:             testAccCheckRoleDestroy(ctx),
-		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyManaged(rName, policyName),
+				Config: testAccRoleConfig_diffs(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyDetachManagedPolicy(ctx, &role, policyName),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyManaged(rName, policyName),
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
-		},
-	})
-}
-
-// TestAccIAMRole_PolicyOutOfBandRemovalAddedBack_inlineNonEmpty: if a policy is removed
-// out of band, it should be recreated.
-func TestAccIAMRole_InlinePolicy_outOfBandRemovalAddedBack(t *testing.T) {
-	ctx := acctest.Context(t)
-	var role iam.Role
+			{
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffs(rName, "tags = {}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffs(rName, "tags = {}"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// https://github.com/hashicorp/terraform-provider-aws/issues/28835
+func TestAccIAMRole_diffsCondition(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -809,32 +695,80 @@ func TestAccIAMRole_InlinePolicy_outOfBandRemovalAddedBack(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyInline(rName, policyName),
+				Config: testAccRoleConfig_diffsCondition(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyInline(rName, policyName),
+				Config:   testAccRoleConfig_diffsCondition(rName),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffsCondition(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_diffsCondition(rName),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_diffsCondition(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
 				),
 			},
+			{
+				Config:   testAccRoleConfig_diffsCondition(rName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-// TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemoved: if managed_policy_arns arg
-// exists and is non-empty, policy attached out of band should be removed
-func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemoved(t *testing.T) {
+func TestAccIAMRole_badJSON(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_badJSON(rName),
+				ExpectError: regexp.MustCompile(`.*contains an invalid JSON:.*`),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_assumeRolePolicyInvalidVersion(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_assumeRolePolicyVersion(rName, "2012-10-18"),
+				ExpectError: regexp.MustCompile(`has Version "2012-10-18", which is not one of "2012-10-17" or "2008-10-17"`),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_disappears(t *testing.T) {
 	ctx := acctest.Context(t)
 	var role iam.Role
+
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -844,32 +778,45 @@ func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemoved(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyExtraManaged(rName, policyName1, policyName2),
+				Config: testAccRoleConfig_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName2),
+					acctest.CheckResourceDisappears(ctx, acctest.Provider, tfiam.ResourceRole(), resourceName),
 				),
 				ExpectNonEmptyPlan: true,
 			},
+		},
+	})
+}
+
+func TestAccIAMRole_skipDestroy(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleRetained(ctx, &role),
+		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyExtraManaged(rName, policyName1, policyName2),
+				Config: testAccRoleConfig_skipDestroy(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "skip_destroy", "true"),
 				),
 			},
 		},
 	})
 }
 
-// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_inlineNonEmpty: if inline_policy arg
-// exists and is non-empty, policy added out of band should be removed
-func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemoved(t *testing.T) {
+func TestAccIAMRole_policiesForceDetach(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -879,33 +826,26 @@ func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemoved(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyInline(rName, policyName1),
+				Config: testAccRoleConfig_forceDetachPolicies(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName2),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccAddRolePolicy(ctx, resourceName),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyInline(rName, policyName1),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
-				),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_detach_policies", "managed_policy_arns_import_pending"},
 			},
 		},
 	})
 }
 
-// TestAccIAMRole_PolicyOutOfBandAdditionIgnored_inlineNonExistent: if there is no
-// inline_policy attribute, out of band changes should be ignored.
-func TestAccIAMRole_InlinePolicy_outOfBandAdditionIgnored(t *testing.T) {
+func TestAccIAMRole_policiesForceDetachAfterImport(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -915,38 +855,41 @@ func TestAccIAMRole_InlinePolicy_outOfBandAdditionIgnored(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyNoInline(rName),
+				Config: testAccRoleConfig_forceDetachPolicies(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName1),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccAddRolePolicy(ctx, resourceName),
 				),
 			},
 			{
-				Config: testAccRoleConfig_policyNoInline(rName),
+				Config:             testAccRoleConfig_forceDetachPolicies(rName),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				// Applying the configuration after import records force_detach_policies without any IAM calls.
+				Config: testAccRoleConfig_forceDetachPolicies(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName2),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "force_detach_policies", "true"),
 				),
 			},
 			{
-				Config: testAccRoleConfig_policyNoInline(rName),
+				// The value survives a refresh, so the destroy below detaches the out-of-band policy.
+				RefreshState: true,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName1),
-					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName2),
+					resource.TestCheckResourceAttr(resourceName, "force_detach_policies", "true"),
 				),
 			},
 		},
 	})
 }
 
-// TestAccIAMRole_PolicyOutOfBandAdditionIgnored_managedNonExistent: if there is no
-// managed_policy_arns attribute, out of band changes should be ignored.
-func TestAccIAMRole_ManagedPolicy_outOfBandAdditionIgnored(t *testing.T) {
+func TestAccIAMRole_instanceProfileRemovedOnDelete(t *testing.T) {
 	ctx := acctest.Context(t)
-	var role iam.Role
+	var conf iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -956,30 +899,33 @@ func TestAccIAMRole_ManagedPolicy_outOfBandAdditionIgnored(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyNoManaged(rName, policyName),
+				Config: testAccRoleConfig_instanceProfile(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName),
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccCheckRoleAddToInstanceProfile(ctx, &conf, rName),
 				),
+				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyNoManaged(rName, policyName),
+				RefreshState: true,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyDetachManagedPolicy(ctx, &role, policyName),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "instance_profile_names.*", rName),
 				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Destroying the role removes it from the profile it was just added to.
+				Config: testAccRoleConfig_instanceProfileOnly(rName),
 			},
 		},
 	})
 }
 
-// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_inlineEmpty: if inline is added
-// out of band with empty inline arg, should be removed
-func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
+func TestAccIAMRole_assumeRolePolicyIgnoreStatements(t *testing.T) {
 	ctx := acctest.Context(t)
 	var role iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -989,30 +935,34 @@ func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Config: testAccRoleConfig_assumeRolePolicyIgnoreStatements(rName, "first"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_ignore_statements.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "assume_role_policy_ignore_statements.*", "ExternallyManaged"),
+					testAccCheckRoleAddAssumeRolePolicyStatement(ctx, &role, "ExternallyManaged", nil),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Config:   testAccRoleConfig_assumeRolePolicyIgnoreStatements(rName, "first"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_assumeRolePolicyIgnoreStatements(rName, "second"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					testAccCheckRoleAssumeRolePolicyHasStatement(&role, "ExternallyManaged"),
 				),
 			},
 		},
 	})
 }
 
-// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_managedEmpty: if managed is attached
-// out of band with empty managed arg, should be detached
-func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
+func TestAccIAMRole_assumeRolePolicyIgnoreStatementsTrustsOrg(t *testing.T) {
 	ctx := acctest.Context(t)
 	var role iam.Role
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_iam_role.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -1022,247 +972,3938 @@ func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
 		CheckDestroy:             testAccCheckRoleDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRoleConfig_policyEmptyManaged(rName, policyName),
+				Config: testAccRoleConfig_assumeRolePolicyIgnoreStatements(rName, "first"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
-					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName),
+					resource.TestCheckResourceAttr(resourceName, "trusts_org_root", "false"),
+					testAccCheckRoleAddAssumeRolePolicyStatement(ctx, &role, "ExternallyManaged", map[string]interface{}{
+						"StringEquals": map[string]interface{}{"aws:PrincipalOrgID": "o-1234567890"},
+					}),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 			{
-				Config: testAccRoleConfig_policyEmptyManaged(rName, policyName),
+				// The organization condition is only in an ignored statement.
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "trusts_org_root", "true"),
+					resource.TestCheckResourceAttrWith(resourceName, "assume_role_policy", func(value string) error {
+						if strings.Contains(value, "ExternallyManaged") {
+							return fmt.Errorf("ignored statement is in state: %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_maxSessionDuration(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_maxSessionDuration(rName, 3599),
+				ExpectError: regexp.MustCompile(`expected max_session_duration to be in the range`),
+			},
+			{
+				Config:      testAccRoleConfig_maxSessionDuration(rName, 43201),
+				ExpectError: regexp.MustCompile(`expected max_session_duration to be in the range`),
+			},
+			{
+				Config: testAccRoleConfig_maxSessionDuration(rName, 3700),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "3700"),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration_is_maximum", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleConfig_maxSessionDuration(rName, 3701),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "3701"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleConfig_maxSessionDuration(rName, 43200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "43200"),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration_is_maximum", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_permissionsBoundary(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	permissionsBoundary1 := fmt.Sprintf("arn:%s:iam::aws:policy/AdministratorAccess", acctest.Partition())
+	permissionsBoundary2 := fmt.Sprintf("arn:%s:iam::aws:policy/ReadOnlyAccess", acctest.Partition())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckUserDestroy(ctx),
+		Steps: []resource.TestStep{
+			// Test creation
+			{
+				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary1),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_name", "AdministratorAccess"),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "true"),
+					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary1),
+				),
+			},
+			// Test update
+			{
+				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary2),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_name", "ReadOnlyAccess"),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "true"),
+					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary2),
+				),
+			},
+			// Test import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",
+				},
+			},
+			// Test removal
+			{
+				Config: testAccRoleConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", ""),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_name", ""),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "false"),
+					testAccCheckRolePermissionsBoundary(&role, ""),
+				),
+			},
+			// Test addition
+			{
+				Config: testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", permissionsBoundary1),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_name", "AdministratorAccess"),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "true"),
+					testAccCheckRolePermissionsBoundary(&role, permissionsBoundary1),
+				),
+			},
+			// Test empty value
+			{
+				Config: testAccRoleConfig_permissionsBoundary(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", ""),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_name", ""),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "false"),
+					testAccCheckRolePermissionsBoundary(&role, ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_validatePermissionsBoundary(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_validatePermissionsBoundary(rName, rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`permissions_boundary \(.+\) does not exist`),
+			},
+			{
+				Config: testAccRoleConfig_validatePermissionsBoundary(rName, "ReadOnlyAccess"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "validate_permissions_boundary", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_tags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckUserDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_tags(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tag1", "test-value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tag2", "test-value2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleConfig_tagsUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tag2", "test-value"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_tagsImport(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+	config := acctest.ConfigCompose(
+		acctest.ConfigDefaultAndIgnoreTagsKeyPrefixes1("providerkey1", "providervalue1", "ignore-"),
+		testAccRoleConfig_tags(rName),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					// An ignored tag added out of band must not show up after import either.
+					testAccCheckRoleAddTag(ctx, &role, "ignore-key1", "ignore-value1"),
+				),
+			},
+			{
+				Config:             config,
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				// The imported tags match the configuration.
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tag1", "test-value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tag2", "test-value2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.providerkey1", "providervalue1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_defaultTags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("providerkey1", "providervalue1"),
+					testAccRoleConfig_tags(rName),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.providerkey1", "providervalue1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.tag1", "test-value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.tag2", "test-value2"),
+					// The merged set is sent on create.
+					testAccCheckRoleHasTag(&role, "providerkey1", "providervalue1"),
+					testAccCheckRoleHasTag(&role, "tag1", "test-value1"),
+				),
+			},
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("tag1", "providervalue1"),
+					testAccRoleConfig_tags(rName),
+				),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					// Resource tags take precedence over provider default tags.
+					resource.TestCheckResourceAttr(resourceName, "tags_all.tag1", "test-value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.tag2", "test-value2"),
 				),
 			},
 		},
 	})
 }
 
-func testAccCheckRoleDestroy(ctx context.Context) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+func TestAccIAMRole_InlinePolicy_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName3 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInline(rName, policyName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "0"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyInlineUpdate(rName, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "0"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyInlineUpdateDown(rName, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"inline_policy.0.policy"},
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_InlinePolicy_identicalDocuments(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInlineIdenticalDocuments(rName, "ec2:Describe*"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "name_prefix", rName),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "2"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_policyInlineIdenticalDocuments(rName, "ec2:Describe*"),
+				PlanOnly: true,
+			},
+			{
+				// A legitimate document change is still planned and applied.
+				Config: testAccRoleConfig_policyInlineIdenticalDocuments(rName, "s3:ListAllMyBuckets"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "2"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_policyInlineIdenticalDocuments(rName, "s3:ListAllMyBuckets"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_InlinePolicy_duplicateNames(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_policyInlineDuplicateNames(rName),
+				ExpectError: regexp.MustCompile(`inline_policy names must be unique`),
+			},
+		},
+	})
+}
+
+// Reference: https://github.com/hashicorp/terraform-provider-aws/issues/19444
+func TestAccIAMRole_InlinePolicy_ignoreOrder(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInlineActionOrder(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_policyInlineActionOrder(rName),
+				PlanOnly: true,
+			},
+			{
+				Config:   testAccRoleConfig_policyInlineActionNewOrder(rName),
+				PlanOnly: true,
+			},
+			{
+				Config:             testAccRoleConfig_policyInlineActionOrderActualDiff(rName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_InlinePolicy_empty(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_ManagedPolicy_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName3 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyManaged(rName, policyName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "1"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyManagedUpdate(rName, policyName1, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "2"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyManagedUpdateDown(rName, policyName1, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"managed_policy_arns_import_pending"},
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_ManagedPolicy_names(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_managedPolicyNames(rName, "/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_names.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_names_path", "/"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "managed_policy_arns.*", "aws_iam_policy.test", "arn"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_managedPolicyNames(rName, "/tf-testing/nested/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_names_path", "/tf-testing/nested/"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "managed_policy_arns.*", "aws_iam_policy.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_ManagedPolicy_namesConflict(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_managedPolicyNamesConflict(rName),
+				ExpectError: regexp.MustCompile(`"managed_policy_names": conflicts with managed_policy_arns`),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandRemovalAddedBack_managedNonEmpty: if a policy is detached
+// out of band, it should be reattached.
+func TestAccIAMRole_ManagedPolicy_outOfBandRemovalAddedBack(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyDetachManagedPolicy(ctx, &role, policyName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandRemovalAddedBack_inlineNonEmpty: if a policy is removed
+// out of band, it should be recreated.
+func TestAccIAMRole_InlinePolicy_outOfBandRemovalAddedBack(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInline(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyInline(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemoved: if managed_policy_arns arg
+// exists and is non-empty, policy attached out of band should be removed
+func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemoved(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyExtraManaged(rName, policyName1, policyName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName2),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyExtraManaged(rName, policyName1, policyName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_inlineNonEmpty: if inline_policy arg
+// exists and is non-empty, policy added out of band should be removed
+func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemoved(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInline(rName, policyName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName2),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyInline(rName, policyName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_InlinePolicy_outOfBandAdditionRetainedNonExclusive: if inline_policy_exclusive
+// is false, inline policies managed by aws_iam_role_policy should not be deleted
+func TestAccIAMRole_InlinePolicy_outOfBandAdditionRetainedNonExclusive(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	var rolePolicy iam.GetRolePolicyOutput
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyInlineNonExclusive(rName, policyName1, policyName2, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_exclusive", "false"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					testAccCheckRolePolicyExists(ctx, resourceName, "aws_iam_role_policy.test", &rolePolicy),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyInlineNonExclusive(rName, policyName1, policyName2, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "inline_policy.*", map[string]string{
+						"name": policyName1,
+					}),
+					testAccCheckRolePolicyExists(ctx, resourceName, "aws_iam_role_policy.test", &rolePolicy),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandAdditionIgnored_inlineNonExistent: if there is no
+// inline_policy attribute, out of band changes should be ignored.
+func TestAccIAMRole_InlinePolicy_outOfBandAdditionIgnored(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyNoInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName1),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyNoInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName2),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyNoInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName1),
+					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, policyName2),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandAdditionIgnored_managedNonExistent: if there is no
+// managed_policy_arns attribute, out of band changes should be ignored.
+func TestAccIAMRole_ManagedPolicy_outOfBandAdditionIgnored(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyNoManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyNoManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyDetachManagedPolicy(ctx, &role, policyName),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_inlineEmpty: if inline is added
+// out of band with empty inline arg, should be removed
+func TestAccIAMRole_InlinePolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, policyName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyEmptyInline(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_PolicyOutOfBandAdditionRemoved_managedEmpty: if managed is attached
+// out of band with empty managed arg, should be detached
+func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRemovedEmpty(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyEmptyManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyEmptyManaged(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+				),
+			},
+		},
+	})
+}
+
+// TestAccIAMRole_ManagedPolicy_outOfBandAdditionRetainedNonExclusive: if
+// managed_policy_arns_exclusive is false, policy attached out of band should survive an apply
+func TestAccIAMRole_ManagedPolicy_outOfBandAdditionRetainedNonExclusive(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyExtraManagedNonExclusive(rName, policyName1, policyName2, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns_exclusive", "false"),
+					testAccCheckRolePolicyAttachManagedPolicy(ctx, &role, policyName2),
+				),
+			},
+			{
+				Config: testAccRoleConfig_policyExtraManagedNonExclusive(rName, policyName1, policyName2, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "managed_policy_arns.*", "aws_iam_policy.test", "arn"),
+					testAccCheckRoleHasManagedPolicy(ctx, &role, policyName2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_assumeRolePolicyDocument(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocument(rName, "ec2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_document.#", "1"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`ec2\.`)),
+				),
+			},
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocument(rName, "lambda"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`lambda\.`)),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"assume_role_policy_document"},
+			},
+			{
+				// Change the trust policy out of band; the block must report drift.
+				Config: testAccRoleConfig_assumeRolePolicyDocument(rName, "lambda"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					testAccCheckRoleUpdateAssumeRolePolicyService(ctx, &conf, "ecs-tasks"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocument(rName, "lambda"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`lambda\.`)),
+					resource.TestCheckResourceAttrWith(resourceName, "assume_role_policy", func(value string) error {
+						if strings.Contains(value, "ecs-tasks") {
+							return fmt.Errorf("out-of-band trust policy change was not reverted: %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_assumeRolePolicyDocumentConditions(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocumentConditions(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_document.0.statement.0.condition.#", "2"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`"sts:ExternalId":"`+rName+`"`)),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`"aws:SourceAccount":`)),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_assumeRolePolicyDocumentConditions(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_AssumeRolePolicyDocument_sessionTagKeys(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocumentSessionTagKeys(rName, `"Team"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_document.0.session_tag_keys.#", "1"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`"sts:TagSession"`)),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`"sts:TransitiveTagKeys":\[?"Team"\]?`)),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_assumeRolePolicyDocumentSessionTagKeys(rName, `"Team"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_assumeRolePolicyDocumentSessionTagKeys(rName, `"Project", "Team"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "assume_role_policy_document.0.session_tag_keys.#", "2"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`"aws:TagKeys":\["Project","Team"\]`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_trustedServices(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_trustedServices(rName, `"ec2.${data.aws_partition.current.dns_suffix}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "trusted_services.#", "1"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`ec2\.`)),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"trusted_services"},
+			},
+			{
+				Config: testAccRoleConfig_trustedServices(rName, `"ec2.${data.aws_partition.current.dns_suffix}", "lambda.${data.aws_partition.current.dns_suffix}"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "trusted_services.#", "2"),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`ec2\.`)),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`lambda\.`)),
+				),
+			},
+			// The policy read back from IAM must be equivalent to the generated one.
+			{
+				Config:   testAccRoleConfig_trustedServices(rName, `"ec2.${data.aws_partition.current.dns_suffix}", "lambda.${data.aws_partition.current.dns_suffix}"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_trustedServicesConflict(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_trustedServicesConflict(rName),
+				ExpectError: regexp.MustCompile("only one of `assume_role_policy,assume_role_policy_document,trusted_services` can be specified"),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_assumeRolePolicyLiteralPercent(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_assumeRolePolicyLiteralPercent(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestMatchResourceAttr(resourceName, "assume_role_policy", regexp.MustCompile(`test-%41\+\*`)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_createTimeout(t *testing.T) {
+	ctx := acctest.Context(t)
+	var conf iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_createTimeout(rName, "5 minutes"),
+				ExpectError: regexp.MustCompile(`cannot be parsed as a duration`),
+			},
+			{
+				Config: testAccRoleConfig_createTimeout(rName, "5m"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "create_timeout", "5m"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_timeout"},
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_forbidIAMFullAccess(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_forbidIAMFullAccess(rName, true),
+				ExpectError: regexp.MustCompile(`allows wildcard IAM actions on all resources and forbid_iam_full_access is set`),
+			},
+			{
+				Config: testAccRoleConfig_forbidIAMFullAccess(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "forbid_iam_full_access", "false"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_trustsOrgRoot(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "trusts_org_root", "false"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_trustsOrgRoot(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "trusts_org_root", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_trimTags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_trimTags(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Key1", "Value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_trimTags(rName, true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_trimTagsDisabled(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_trimTags(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, " Key1 ", " Value1 "),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_adoptExternalTags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_adoptExternalTags(rName, "Value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleAddTag(ctx, &role, "CostCenter", "1234"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_adoptExternalTags(rName, "Value1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "adopted_tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "adopted_tags.CostCenter", "1234"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_adoptExternalTags(rName, "Value1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_adoptExternalTags(rName, "Value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Key1", "Value2"),
+					testAccCheckRoleHasTag(&role, "CostCenter", "1234"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "adopted_tags.CostCenter", "1234"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_autoTagName(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Name", rName),
+					testAccCheckRoleHasTag(&role, "Key1", "Value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+				),
+			},
+			{
+				Config:   testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccRoleConfig_autoTagName(rName, true, "Value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Name", rName),
+					testAccCheckRoleHasTag(&role, "Key1", "Value2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_autoTagName(rName, false, "Value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleNoTag(&role, "Name"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_autoTagName(rName, true, "Value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Name", rName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_autoTagNameDefaultTags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("providerkey1", "providervalue1"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Name", rName),
+					testAccCheckRoleHasTag(&role, "providerkey1", "providervalue1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+				),
+			},
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("providerkey1", "providervalue1"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				PlanOnly: true,
+			},
+			{
+				// A Name tag from default_tags takes precedence over the automatic tag.
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("Name", "providervalue1"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleHasTag(&role, "Name", "providervalue1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Name", "providervalue1"),
+				),
+			},
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultTags_Tags1("Name", "providervalue1"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_autoTagNameIgnoreTags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigIgnoreTagsKeys("Name"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleNoTag(&role, "Name"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigIgnoreTagsKeys("Name"),
+					testAccRoleConfig_autoTagName(rName, true, "Value1"),
+				),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_ignoreTagPrefixesOverridesProvider(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ConfigCompose(
+					acctest.ConfigIgnoreTagsKeys("ignorekey1"),
+					testAccRoleConfig_ignoreTagPrefixes(rName, "tooling:"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRoleAddTag(ctx, &role, "ignorekey1", "value1"),
+					testAccCheckRoleAddTag(ctx, &role, "tooling:owner", "value2"),
+				),
+				// The globally ignored tag is surfaced for this role, so it is planned for removal.
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.key1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.ignorekey1", "value1"),
+					resource.TestCheckNoResourceAttr(resourceName, "tags_all.tooling:owner"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_inlinePolicies(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	externalPolicyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    read = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "ec2:Describe*", Resource = "*" }]
+    })
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "inline_policies.read"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "1"),
+				),
+			},
+			{
+				// Add a policy by key.
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    read = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "ec2:Describe*", Resource = "*" }]
+    })
+    list = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
+    })
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "inline_policies.list"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "2"),
+				),
+			},
+			{
+				// Change a policy by key.
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    read = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = ["ec2:Describe*", "s3:GetObject"], Resource = "*" }]
+    })
+    list = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
+    })
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "2"),
+					resource.TestMatchResourceAttr(resourceName, "inline_policies.read", regexp.MustCompile(`s3:GetObject`)),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "2"),
+				),
+			},
+			{
+				// Remove a policy by key.
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    list = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
+    })
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "1"),
+					resource.TestCheckNoResourceAttr(resourceName, "inline_policies.read"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "1"),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, externalPolicyName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// The policy added outside Terraform is removed.
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    list = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
+    })
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy_count", "1"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_inlinePolicies(rName, `
+    list = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
+    })
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_normalizeInlinePolicies(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_normalizeInlinePolicies(rName, `
+    read = <<EOF
+{
+  "Statement": [
+    {
+      "Resource": "*",
+      "Effect": "Allow",
+      "Action": "ec2:Describe*"
+    }
+  ],
+  "Version": "2012-10-17"
+}
+EOF
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "normalize_inline_policies", "true"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policies.read", `{"Version":"2012-10-17","Statement":[{"Action":"ec2:Describe*","Effect":"Allow","Resource":"*"}]}`),
+				),
+			},
+			{
+				// Reformatted input is not a diff.
+				Config: testAccRoleConfig_normalizeInlinePolicies(rName, `
+    read = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "ec2:Describe*", Resource = "*" }]
+    })
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_inlinePoliciesConflictsWithInlinePolicy(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleConfig_inlinePoliciesConflict(rName),
+				ExpectError: regexp.MustCompile(`"inline_policies": conflicts with inline_policy`),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_forbidExternalInlinePolicyDeletion(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	externalPolicyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_forbidExternalInlinePolicyDeletion(rName, policyName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "forbid_external_inline_policy_deletion", "true"),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, externalPolicyName),
+				),
+			},
+			{
+				Config:      testAccRoleConfig_forbidExternalInlinePolicyDeletion(rName, policyName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`not managed by this resource, and would be deleted with it: ` + regexp.QuoteMeta(externalPolicyName)),
+			},
+			{
+				Config: testAccRoleConfig_forbidExternalInlinePolicyDeletion(rName, policyName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "forbid_external_inline_policy_deletion", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_destroyManagedInlinePoliciesOnly(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	externalPolicyName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_destroyManagedInlinePoliciesOnly(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "destroy_managed_inline_policies_only", "true"),
+					testAccCheckRolePolicyAddInlinePolicy(ctx, &role, externalPolicyName),
+				),
+			},
+			{
+				Config:      testAccRoleConfig_destroyManagedInlinePoliciesOnly(rName, policyName),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`role still has inline policies that are not managed by this resource: ` + regexp.QuoteMeta(externalPolicyName)),
+			},
+			{
+				// The external policy was kept, so it can still be removed.
+				Config: testAccRoleConfig_destroyManagedInlinePoliciesOnly(rName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					testAccCheckRolePolicyRemoveInlinePolicy(ctx, &role, externalPolicyName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_ManagedPolicy_afterImport(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName1 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName2 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	policyName3 := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_policyManagedUpdate(rName, policyName1, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "2"),
+				),
+			},
+			{
+				Config:             testAccRoleConfig_policyManagedUpdate(rName, policyName1, policyName2, policyName3),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				// The first apply after import does not detach the policy that the configuration no longer lists.
+				Config: testAccRoleConfig_policyManagedUpdateDown(rName, policyName1, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns_import_pending", "false"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRoleConfig_policyManagedUpdateDown(rName, policyName1, policyName2, policyName3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_arns.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policy_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_computeBoundaryAnalysis(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_computeBoundaryAnalysis(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "managed_policies_exceeding_boundary.#", "0"),
+				),
+			},
+			{
+				Config: testAccRoleConfig_computeBoundaryAnalysis(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "compute_boundary_analysis", "true"),
+					resource.TestCheckResourceAttr(resourceName, "managed_policies_exceeding_boundary.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "managed_policies_exceeding_boundary.*", "aws_iam_policy.broad", "arn"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"compute_boundary_analysis", "managed_policies_exceeding_boundary"},
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_computeBoundaryDocument(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_computeBoundaryDocument(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary_document", ""),
+				),
+			},
+			{
+				Config: testAccRoleConfig_computeBoundaryDocument(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					acctest.CheckResourceAttrEquivalentJSON(resourceName, "permissions_boundary_document", `{"Version":"2012-10-17","Statement":[{"Action":["s3:Get*","s3:List*"],"Effect":"Allow","Resource":"*"}]}`),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"compute_boundary_document", "permissions_boundary_document"},
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_deletionProtectionTag(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_deletionProtectionTag(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					// Tagged outside Terraform, so the protection is only known to IAM.
+					testAccCheckRoleAddTag(ctx, &role, "terraform:protected", "true"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccRoleConfig_deletionProtectionTag(rName),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`role is protected from deletion by its terraform:protected tag`),
+			},
+			{
+				// Applying the configuration removes the tag, so the role can then be destroyed.
+				Config: testAccRoleConfig_deletionProtectionTag(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_createInstanceProfile(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckRoleDestroy(ctx),
+			testAccCheckRoleInstanceProfileNotExists(ctx, rName),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_createInstanceProfile(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "create_instance_profile", "true"),
+					acctest.CheckResourceAttrGlobalARN(resourceName, "instance_profile_arn", "iam", fmt.Sprintf("instance-profile/%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "instance_profile_names.*", rName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_instance_profile", "instance_profile_arn"},
+			},
+			{
+				Config: testAccRoleConfig_createInstanceProfile(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_arn", ""),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_names.#", "0"),
+					testAccCheckRoleInstanceProfileNotExists(ctx, rName),
+				),
+			},
+			{
+				Config: testAccRoleConfig_createInstanceProfile(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "instance_profile_names.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_updateFailure(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_updateFailure(rName, "first", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+				),
+			},
+			{
+				// The permissions boundary update fails while the description update succeeds.
+				Config:      testAccRoleConfig_updateFailure(rName, "second", true),
+				ExpectError: regexp.MustCompile(`updating permissions boundary`),
+			},
+			{
+				Config: testAccRoleConfig_updateFailure(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "permissions_boundary", ""),
+					resource.TestCheckResourceAttr(resourceName, "has_permissions_boundary", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRole_updateMultipleAttributes(t *testing.T) {
+	ctx := acctest.Context(t)
+	var role iam.Role
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckRoleDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+				),
+			},
+			{
+				Config: testAccRoleConfig_multipleAttributes(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists(ctx, resourceName, &role),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated"),
+					resource.TestCheckResourceAttr(resourceName, "max_session_duration", "7200"),
+					resource.TestCheckResourceAttr(resourceName, "inline_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Key1", "Value1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRoleDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_iam_role" {
+				continue
+			}
+
+			_, err := tfiam.FindRoleByName(ctx, conn, rs.Primary.ID)
+
+			if tfresource.NotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("IAM Role %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+// testAccRolePlanCheckKnownValue returns a plan check that the planned value of a string attribute is known and equal to want.
+func testAccRolePlanCheckKnownValue(resourceAddress, attributeName, want string) plancheck.PlanCheck {
+	return testAccRoleKnownValuePlanCheck{
+		resourceAddress: resourceAddress,
+		attributeName:   attributeName,
+		want:            want,
+	}
+}
+
+type testAccRoleKnownValuePlanCheck struct {
+	resourceAddress string
+	attributeName   string
+	want            string
+}
+
+func (c testAccRoleKnownValuePlanCheck) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != c.resourceAddress {
+			continue
+		}
+
+		if afterUnknown, ok := rc.Change.AfterUnknown.(map[string]interface{}); ok {
+			if unknown, ok := afterUnknown[c.attributeName].(bool); ok && unknown {
+				resp.Error = fmt.Errorf("%s: %s is unknown in the plan", c.resourceAddress, c.attributeName)
+				return
+			}
+		}
+
+		after, _ := rc.Change.After.(map[string]interface{})
+		if got, _ := after[c.attributeName].(string); got != c.want {
+			resp.Error = fmt.Errorf("%s: planned %s is %q, want %q", c.resourceAddress, c.attributeName, got, c.want)
+		}
+
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s not found in plan", c.resourceAddress)
+}
+
+// testAccCheckRoleCreateDateUnix verifies that create_date_unix is create_date as Unix epoch seconds.
+func testAccCheckRoleCreateDateUnix(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		createDate, err := time.Parse(time.RFC3339, rs.Primary.Attributes["create_date"])
+		if err != nil {
+			return fmt.Errorf("parsing create_date: %w", err)
+		}
+
+		if got, want := rs.Primary.Attributes["create_date_unix"], strconv.FormatInt(createDate.Unix(), 10); got != want {
+			return fmt.Errorf("create_date_unix is %s, want %s", got, want)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckRoleRetained checks that the role was left in place by destroy and then deletes it.
+func testAccCheckRoleRetained(ctx context.Context, v *iam.Role) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		roleName := aws.StringValue(v.RoleName)
+
+		if _, err := tfiam.FindRoleByName(ctx, conn, roleName); err != nil {
+			return fmt.Errorf("IAM Role %s not retained: %w", roleName, err)
+		}
+
+		return tfiam.DeleteRole(ctx, conn, roleName, true, false, false, nil, 2*time.Minute)
+	}
+}
+
+func testAccCheckRoleExists(ctx context.Context, n string, v *iam.Role) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No IAM Role ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		output, err := tfiam.FindRoleByName(ctx, conn, rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		*v = *output
+
+		return nil
+	}
+}
+
+// Attach inline policy out of band (outside of terraform)
+func testAccAddRolePolicy(ctx context.Context, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found")
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Role name is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		input := &iam.PutRolePolicyInput{
+			RoleName: aws.String(rs.Primary.ID),
+			PolicyDocument: aws.String(`{
+  "Version": "2012-10-17",
+  "Statement": {
+    "Effect": "Allow",
+    "Action": "*",
+    "Resource": "*"
+  }
+}`),
+			PolicyName: aws.String(id.UniqueId()),
+		}
+
+		_, err := conn.PutRolePolicyWithContext(ctx, input)
+		return err
+	}
+}
+
+func testAccCheckRolePermissionsBoundary(role *iam.Role, expectedPermissionsBoundaryArn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		actualPermissionsBoundaryArn := ""
+
+		if role.PermissionsBoundary != nil {
+			actualPermissionsBoundaryArn = *role.PermissionsBoundary.PermissionsBoundaryArn
+		}
+
+		if actualPermissionsBoundaryArn != expectedPermissionsBoundaryArn {
+			return fmt.Errorf("PermissionsBoundary: '%q', expected '%q'.", actualPermissionsBoundaryArn, expectedPermissionsBoundaryArn)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRolePolicyDetachManagedPolicy(ctx context.Context, role *iam.Role, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		var managedARN string
+		input := &iam.ListAttachedRolePoliciesInput{
+			RoleName: role.RoleName,
+		}
+
+		err := conn.ListAttachedRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+			for _, v := range page.AttachedPolicies {
+				if *v.PolicyName == policyName {
+					managedARN = *v.PolicyArn
+					break
+				}
+			}
+			return !lastPage
+		})
+		if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			return fmt.Errorf("finding managed policy (%s): %w", policyName, err)
+		}
+		if managedARN == "" {
+			return fmt.Errorf("managed policy (%s) not found", policyName)
+		}
+
+		_, err = conn.DetachRolePolicyWithContext(ctx, &iam.DetachRolePolicyInput{
+			PolicyArn: aws.String(managedARN),
+			RoleName:  role.RoleName,
+		})
+
+		return err
+	}
+}
+
+func testAccCheckRolePolicyAttachManagedPolicy(ctx context.Context, role *iam.Role, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		var managedARN string
+		input := &iam.ListPoliciesInput{
+			PathPrefix:        aws.String("/tf-testing/"),
+			PolicyUsageFilter: aws.String("PermissionsPolicy"),
+			Scope:             aws.String("Local"),
+		}
+
+		err := conn.ListPoliciesPagesWithContext(ctx, input, func(page *iam.ListPoliciesOutput, lastPage bool) bool {
+			for _, v := range page.Policies {
+				if *v.PolicyName == policyName {
+					managedARN = *v.Arn
+					break
+				}
+			}
+			return !lastPage
+		})
+		if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			return fmt.Errorf("finding managed policy (%s): %w", policyName, err)
+		}
+		if managedARN == "" {
+			return fmt.Errorf("managed policy (%s) not found", policyName)
+		}
+
+		_, err = conn.AttachRolePolicyWithContext(ctx, &iam.AttachRolePolicyInput{
+			PolicyArn: aws.String(managedARN),
+			RoleName:  role.RoleName,
+		})
+
+		return err
+	}
+}
+
+func testAccCheckRolePolicyAddInlinePolicy(ctx context.Context, role *iam.Role, inlinePolicy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		_, err := conn.PutRolePolicyWithContext(ctx, &iam.PutRolePolicyInput{
+			PolicyDocument: aws.String(testAccRolePolicyExtraInlineConfig()),
+			PolicyName:     aws.String(inlinePolicy),
+			RoleName:       role.RoleName,
+		})
+
+		return err
+	}
+}
+
+func testAccCheckRolePolicyRemoveInlinePolicy(ctx context.Context, role *iam.Role, inlinePolicy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		_, err := conn.DeleteRolePolicyWithContext(ctx, &iam.DeleteRolePolicyInput{
+			PolicyName: aws.String(inlinePolicy),
+			RoleName:   role.RoleName,
+		})
+
+		return err
+	}
+}
+
+func testAccRoleConfig_maxSessionDuration(rName string, maxSessionDuration int) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name                 = %[1]q
+  path                 = "/"
+  max_session_duration = %[2]d
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName, maxSessionDuration)
+}
+
+func testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  name                 = %[1]q
+  path                 = "/"
+  permissions_boundary = %[2]q
+}
+`, rName, permissionsBoundary)
+}
+
+func testAccRoleConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_diffs(rName, tags string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_user" "user1" {
+  name = "%[1]s-baa204a2"
+  path = "/"
+}
+
+resource "aws_iam_user" "user2" {
+  name = "%[1]s-fee06121"
+  path = "/"
+}
+
+resource "aws_iam_user" "user3" {
+  name = "%[1]s-2f0d132b"
+  path = "/"
+}
+
+resource "aws_iam_user" "user4" {
+  name = "%[1]s-d1eaee06"
+  path = "/"
+}
+
+resource "aws_iam_user" "user5" {
+  name = "%[1]s-d4a38c26"
+  path = "/"
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = jsonencode({
+    Id      = %[1]q
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Principal = {
+        AWS = [
+          aws_iam_user.user1.arn,
+          aws_iam_user.user2.arn,
+          aws_iam_user.user3.arn,
+          aws_iam_user.user4.arn,
+          aws_iam_user.user5.arn,
+        ]
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  %[2]s
+}
+`, rName, tags)
+}
+
+func testAccRoleConfig_diffsCondition(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_user" "user1" {
+  name = "%[1]s-cde2c453"
+  path = "/"
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRoleWithSAML"
+      Condition = {
+        IpAddress = {
+          "aws:SourceIp" = [
+            "0.0.0.0/0",
+          ]
+        }
+        StringEquals = {
+          "SAML:aud" = "https://signin.aws.amazon.com/saml"
+        }
+      }
+      Principal = {
+        AWS = [
+          aws_iam_user.user1.arn,
+        ]
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_description(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name        = %[1]q
+  description = "This 1s a D3scr!pti0n with weird content: &@90ë\"'{«¡Çø}"
+  path        = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_updatedDescription(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name        = %[1]q
+  description = "This 1s an Upd@ted D3scr!pti0n with weird content: &90ë\"'{«¡Çø}"
+  path        = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_nameGenerated() string {
+	return `
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  path = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`
+}
+
+func testAccRoleConfig_namePrefix(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name_prefix = %[1]q
+  path        = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_pre(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/test/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+
+resource "aws_iam_role_policy" "role_update_test" {
+  name = "%[1]s-2"
+  role = aws_iam_role.test.id
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "s3:GetBucketLocation",
+        "s3:ListAllMyBuckets"
+      ],
+      "Resource": "arn:${data.aws_partition.current.partition}:s3:::*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_instance_profile" "role_update_test" {
+  name = "%[1]s-2"
+  path = "/test/"
+  role = aws_iam_role.test.name
+}
+`, rName)
+}
+
+func testAccRoleConfig_post(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/test/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+
+resource "aws_iam_role_policy" "role_update_test" {
+  name = "%[1]s-2"
+  role = aws_iam_role.test.id
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "s3:GetBucketLocation",
+        "s3:ListAllMyBuckets"
+      ],
+      "Resource": "arn:${data.aws_partition.current.partition}:s3:::*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_instance_profile" "role_update_test" {
+  name = "%[1]s-2"
+  path = "/test/"
+  role = aws_iam_role.test.name
+}
+`, rName)
+}
+
+func testAccRoleConfig_badJSON(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<INTENTIONALLYBAD
+{
+  "Version": "2012-10-17",
+  "Statement": [
+  {
+    "Action": "sts:AssumeRole",
+    "Principal": {
+    "Service": "ec2.${data.aws_partition.current.dns_suffix}",
+    },
+    "Effect": "Allow",
+    "Sid": ""
+  }
+  ]
+}
+INTENTIONALLYBAD
+}
+`, rName)
+}
+
+func testAccRoleConfig_forceDetachPolicies(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role_policy" "test" {
+  name = %[1]q
+  role = aws_iam_role.test.id
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "ec2:Describe*"
+      ],
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test" {
+  name        = %[1]q
+  description = "A test policy"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "iam:ChangePassword"
+      ],
+      "Resource": "*",
+      "Effect": "Allow"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  role       = aws_iam_role.test.name
+  policy_arn = aws_iam_policy.test.arn
+}
+
+resource "aws_iam_role" "test" {
+  name                  = %[1]q
+  force_detach_policies = true
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccRoleConfig_tags(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  tags = {
+    tag1 = "test-value1"
+    tag2 = "test-value2"
+  }
+}
+`, rName)
+}
+
+func testAccRoleConfig_tagsUpdate(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  tags = {
+    tag2 = "test-value"
+  }
+}
+`, rName)
+}
+
+func testAccRoleConfig_policyInline(roleName, policyName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[2]q
+
+    policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "ec2:Describe*"
+      ],
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+  }
+}
+`, roleName, policyName)
+}
+
+func testAccRoleConfig_policyInlineUpdate(roleName, policyName2, policyName3 string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[2]q
+
+    policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "ec2:Describe*"
+      ],
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+  }
+
+  inline_policy {
+    name = %[3]q
+
+    policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "ec2:Describe*"
+      ],
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+  }
+}
+`, roleName, policyName2, policyName3)
+}
+
+func testAccRoleConfig_policyInlineUpdateDown(roleName, policyName3 string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[2]q
+
+    policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": {
+    "Effect": "Allow",
+    "Action": "ec2:Describe*",
+    "Resource": "*",
+    "Condition": {
+      "DateGreaterThan": {"aws:CurrentTime": "2017-07-01T00:00:00Z"},
+      "DateLessThan": {"aws:CurrentTime": "2017-12-31T23:59:59Z"}
+    }
+  }
+}
+EOF
+  }
+}
+`, roleName, policyName3)
+}
+
+func testAccRoleConfig_policyInlineActionOrder(roleName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action = [
+          "ec2:DescribeScheduledInstances",
+          "ec2:DescribeScheduledInstanceAvailability",
+          "ec2:DescribeFastSnapshotRestores",
+          "ec2:DescribeElasticGpus",
+        ]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+`, roleName)
+}
+
+func testAccRoleConfig_policyInlineActionOrderActualDiff(roleName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action = [
+          "ec2:DescribeScheduledInstances",
+          "ec2:DescribeElasticGpus",
+          "ec2:DescribeScheduledInstanceAvailability",
+        ]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+`, roleName)
+}
+
+func testAccRoleConfig_policyInlineActionNewOrder(roleName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action = [
+          "ec2:DescribeElasticGpus",
+          "ec2:DescribeScheduledInstances",
+          "ec2:DescribeFastSnapshotRestores",
+          "ec2:DescribeScheduledInstanceAvailability",
+        ]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+`, roleName)
+}
+
+func testAccRoleConfig_policyManaged(roleName, policyName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role" "test" {
+  name                = %[2]q
+  managed_policy_arns = [aws_iam_policy.test.arn]
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, policyName, roleName)
+}
+
+func testAccRoleConfig_policyManagedUpdate(roleName, policyName1, policyName2, policyName3 string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test2" {
+  name = %[2]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test3" {
+  name = %[3]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role" "test" {
+  name                = %[4]q
+  managed_policy_arns = [aws_iam_policy.test2.arn, aws_iam_policy.test3.arn]
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, policyName1, policyName2, policyName3, roleName)
+}
+
+func testAccRoleConfig_policyManagedUpdateDown(roleName, policyName1, policyName2, policyName3 string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test2" {
+  name = %[2]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test3" {
+  name = %[3]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role" "test" {
+  name                = %[4]q
+  managed_policy_arns = [aws_iam_policy.test3.arn]
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, policyName1, policyName2, policyName3, roleName)
+}
+
+func testAccRoleConfig_policyExtraManaged(roleName, policyName1, policyName2 string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_policy" "test2" {
+  name = %[2]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role" "test" {
+  name                = %[3]q
+  managed_policy_arns = [aws_iam_policy.test.arn]
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, policyName1, policyName2, roleName)
+}
+
+func testAccRolePolicyExtraInlineConfig() string {
+	return `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+		"Action": [
+			"ec2:Describe*"
+		],
+		"Effect": "Allow",
+		"Resource": "*"
+		}
+	]
+}`
+}
+
+func testAccRoleConfig_policyNoInline(roleName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, roleName)
+}
+
+func testAccRoleConfig_policyNoManaged(roleName, policyName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+
+resource "aws_iam_policy" "managed-policy1" {
+  name = %[2]q
+  path = "/tf-testing/"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+`, roleName, policyName)
+}
+
+func testAccRoleConfig_policyEmptyInline(roleName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  inline_policy {}
+}
+`, roleName)
+}
+
+func testAccRoleConfig_policyEmptyManaged(roleName, policyName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+
+  managed_policy_arns = []
+}
+
+resource "aws_iam_policy" "managed-policy1" {
+  name = %[2]q
+  path = "/tf-testing/"
 
-		for _, rs := range s.RootModule().Resources {
-			if rs.Type != "aws_iam_role" {
-				continue
-			}
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+    "Action": [
+      "ec2:Describe*"
+    ],
+    "Effect": "Allow",
+    "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+`, roleName, policyName)
+}
 
-			_, err := tfiam.FindRoleByName(ctx, conn, rs.Primary.ID)
+func testAccRoleConfig_forbidIAMFullAccess(rName string, forbid bool) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-			if tfresource.NotFound(err) {
-				continue
-			}
+resource "aws_iam_role" "test" {
+  name                   = %[1]q
+  forbid_iam_full_access = %[2]t
 
-			if err != nil {
-				return err
-			}
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+    }]
+  })
 
-			return fmt.Errorf("IAM Role %s still exists", rs.Primary.ID)
-		}
+  inline_policy {
+    name = %[1]q
 
-		return nil
-	}
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = "iam:*"
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+`, rName, forbid)
 }
 
-func testAccCheckRoleExists(ctx context.Context, n string, v *iam.Role) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Not found: %s", n)
-		}
+func testAccRoleConfig_multipleAttributes(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("No IAM Role ID is set")
-		}
+resource "aws_iam_role" "test" {
+  name                 = %[1]q
+  path                 = "/"
+  description          = "updated"
+  max_session_duration = 7200
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "lambda.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+    }]
+  })
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = "ec2:Describe*"
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+
+  tags = {
+    Key1 = "Value1"
+  }
+}
+`, rName)
+}
+
+func testAccRoleConfig_trustsOrgRoot(rName string) string {
+	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        AWS = data.aws_caller_identity.current.account_id
+      }
+      Effect = "Allow"
+      Condition = {
+        StringEquals = {
+          "aws:PrincipalOrgID" = "o-abcdef1234"
+        }
+      }
+    }]
+  })
+}
+`, rName)
+}
 
+// testAccCheckRoleRecreateWithPath deletes the role and creates a role with the same name and trust policy at the specified path.
+func testAccCheckRoleRecreateWithPath(ctx context.Context, role *iam.Role, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
 
-		output, err := tfiam.FindRoleByName(ctx, conn, rs.Primary.ID)
+		if _, err := conn.DeleteRoleWithContext(ctx, &iam.DeleteRoleInput{RoleName: role.RoleName}); err != nil {
+			return err
+		}
 
+		assumeRolePolicy, err := tfiam.DecodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
 		if err != nil {
 			return err
 		}
 
-		*v = *output
+		_, err = tfiam.RetryCreateRole(ctx, conn, &iam.CreateRoleInput{
+			AssumeRolePolicyDocument: aws.String(assumeRolePolicy),
+			Path:                     aws.String(path),
+			RoleName:                 role.RoleName,
+		}, 2*time.Minute)
 
-		return nil
+		return err
 	}
 }
 
-// Attach inline policy out of band (outside of terraform)
-func testAccAddRolePolicy(ctx context.Context, n string) resource.TestCheckFunc {
+func testAccCheckRoleAddTag(ctx context.Context, role *iam.Role, key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Resource not found")
-		}
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("No Role name is set")
-		}
-
 		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
 
-		input := &iam.PutRolePolicyInput{
-			RoleName: aws.String(rs.Primary.ID),
-			PolicyDocument: aws.String(`{
-  "Version": "2012-10-17",
-  "Statement": {
-    "Effect": "Allow",
-    "Action": "*",
-    "Resource": "*"
-  }
-}`),
-			PolicyName: aws.String(id.UniqueId()),
-		}
+		_, err := conn.TagRoleWithContext(ctx, &iam.TagRoleInput{
+			RoleName: role.RoleName,
+			Tags:     []*iam.Tag{{Key: aws.String(key), Value: aws.String(value)}},
+		})
 
-		_, err := conn.PutRolePolicyWithContext(ctx, input)
 		return err
 	}
 }
 
-func testAccCheckRolePermissionsBoundary(role *iam.Role, expectedPermissionsBoundaryArn string) resource.TestCheckFunc {
+func testAccCheckRoleHasTag(role *iam.Role, key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		actualPermissionsBoundaryArn := ""
-
-		if role.PermissionsBoundary != nil {
-			actualPermissionsBoundaryArn = *role.PermissionsBoundary.PermissionsBoundaryArn
-		}
+		for _, tag := range role.Tags {
+			if aws.StringValue(tag.Key) == key {
+				if actual := aws.StringValue(tag.Value); actual != value {
+					return fmt.Errorf("IAM Role tag (%q) value: expected %q, got %q", key, value, actual)
+				}
 
-		if actualPermissionsBoundaryArn != expectedPermissionsBoundaryArn {
-			return fmt.Errorf("PermissionsBoundary: '%q', expected '%q'.", actualPermissionsBoundaryArn, expectedPermissionsBoundaryArn)
+				return nil
+			}
 		}
 
-		return nil
+		return fmt.Errorf("IAM Role tag (%q) not found", key)
 	}
 }
 
-func testAccCheckRolePolicyDetachManagedPolicy(ctx context.Context, role *iam.Role, policyName string) resource.TestCheckFunc {
+func testAccRoleConfig_trimTags(rName string, trim bool) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name      = %[1]q
+  trim_tags = %[2]t
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+    }]
+  })
+
+  tags = {
+    " Key1 " = " Value1 "
+  }
+}
+`, rName, trim)
+}
+
+func testAccCheckRoleHasManagedPolicy(ctx context.Context, role *iam.Role, policyName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
 
-		var managedARN string
+		var found bool
 		input := &iam.ListAttachedRolePoliciesInput{
 			RoleName: role.RoleName,
 		}
 
 		err := conn.ListAttachedRolePoliciesPagesWithContext(ctx, input, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
 			for _, v := range page.AttachedPolicies {
-				if *v.PolicyName == policyName {
-					managedARN = *v.PolicyArn
-					break
+				if aws.StringValue(v.PolicyName) == policyName {
+					found = true
+					return false
 				}
 			}
 			return !lastPage
 		})
-		if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-			return fmt.Errorf("finding managed policy (%s): %w", policyName, err)
+		if err != nil {
+			return fmt.Errorf("listing IAM Role (%s) attached policies: %w", aws.StringValue(role.RoleName), err)
 		}
-		if managedARN == "" {
-			return fmt.Errorf("managed policy (%s) not found", policyName)
+
+		if !found {
+			return fmt.Errorf("managed policy (%s) not attached to IAM Role (%s)", policyName, aws.StringValue(role.RoleName))
 		}
 
-		_, err = conn.DetachRolePolicyWithContext(ctx, &iam.DetachRolePolicyInput{
-			PolicyArn: aws.String(managedARN),
-			RoleName:  role.RoleName,
-		})
+		return nil
+	}
+}
+
+func testAccRoleConfig_policyExtraManagedNonExclusive(roleName, policyName1, policyName2, description string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_policy" "test" {
+  name = %[1]q
+  path = "/tf-testing/"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["ec2:Describe*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_policy" "test2" {
+  name = %[2]q
+  path = "/tf-testing/"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["ec2:Describe*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_role" "test" {
+  name                          = %[3]q
+  description                   = %[4]q
+  force_detach_policies         = true
+  managed_policy_arns           = [aws_iam_policy.test.arn]
+  managed_policy_arns_exclusive = false
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole",
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+      }
+      Effect = "Allow"
+      Sid    = ""
+    }]
+  })
+}
+`, policyName1, policyName2, roleName, description)
+}
+
+func testAccRoleConfig_assumeRolePolicyDocument(rName, service string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-		return err
-	}
-}
+resource "aws_iam_role" "test" {
+  name = %[1]q
 
-func testAccCheckRolePolicyAttachManagedPolicy(ctx context.Context, role *iam.Role, policyName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+  assume_role_policy_document {
+    statement {
+      actions = ["sts:AssumeRole"]
 
-		var managedARN string
-		input := &iam.ListPoliciesInput{
-			PathPrefix:        aws.String("/tf-testing/"),
-			PolicyUsageFilter: aws.String("PermissionsPolicy"),
-			Scope:             aws.String("Local"),
-		}
+      principals {
+        type        = "Service"
+        identifiers = ["%[2]s.${data.aws_partition.current.dns_suffix}"]
+      }
+    }
+  }
+}
+`, rName, service)
+}
 
-		err := conn.ListPoliciesPagesWithContext(ctx, input, func(page *iam.ListPoliciesOutput, lastPage bool) bool {
-			for _, v := range page.Policies {
-				if *v.PolicyName == policyName {
-					managedARN = *v.Arn
-					break
-				}
-			}
-			return !lastPage
-		})
-		if err != nil && !tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
-			return fmt.Errorf("finding managed policy (%s): %w", policyName, err)
-		}
-		if managedARN == "" {
-			return fmt.Errorf("managed policy (%s) not found", policyName)
-		}
+func testAccRoleConfig_createTimeout(rName, timeout string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-		_, err = conn.AttachRolePolicyWithContext(ctx, &iam.AttachRolePolicyInput{
-			PolicyArn: aws.String(managedARN),
-			RoleName:  role.RoleName,
-		})
+resource "aws_iam_role" "test" {
+  name           = %[1]q
+  create_timeout = %[2]q
 
-		return err
-	}
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+}
+`, rName, timeout)
 }
 
-func testAccCheckRolePolicyAddInlinePolicy(ctx context.Context, role *iam.Role, inlinePolicy string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+func testAccRoleConfig_nameEmpty() string {
+	return `
+variable "name" {
+  default = ""
+}
 
-		_, err := conn.PutRolePolicyWithContext(ctx, &iam.PutRolePolicyInput{
-			PolicyDocument: aws.String(testAccRolePolicyExtraInlineConfig()),
-			PolicyName:     aws.String(inlinePolicy),
-			RoleName:       role.RoleName,
-		})
+data "aws_partition" "current" {}
 
-		return err
-	}
+resource "aws_iam_role" "test" {
+  name = var.name
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+}
+`
 }
 
-func testAccCheckRolePolicyRemoveInlinePolicy(ctx context.Context, role *iam.Role, inlinePolicy string) resource.TestCheckFunc {
+func testAccCheckRoleAddToInstanceProfile(ctx context.Context, role *iam.Role, instanceProfileName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
 
-		_, err := conn.DeleteRolePolicyWithContext(ctx, &iam.DeleteRolePolicyInput{
-			PolicyName: aws.String(inlinePolicy),
-			RoleName:   role.RoleName,
+		_, err := conn.AddRoleToInstanceProfileWithContext(ctx, &iam.AddRoleToInstanceProfileInput{
+			InstanceProfileName: aws.String(instanceProfileName),
+			RoleName:            role.RoleName,
 		})
 
 		return err
 	}
 }
 
-func testAccRoleConfig_maxSessionDuration(rName string, maxSessionDuration int) string {
+func testAccRoleConfig_instanceProfileOnly(rName string) string {
 	return fmt.Sprintf(`
+resource "aws_iam_instance_profile" "test" {
+  name = %[1]q
+}
+`, rName)
+}
+
+func testAccRoleConfig_instanceProfile(rName string) string {
+	return acctest.ConfigCompose(testAccRoleConfig_instanceProfileOnly(rName), fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name                 = %[1]q
-  path                 = "/"
-  max_session_duration = %[2]d
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
-      Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-`, rName, maxSessionDuration)
+`, rName))
 }
 
-func testAccRoleConfig_permissionsBoundary(rName, permissionsBoundary string) string {
+func testAccRoleConfig_policyInlineNonExclusive(roleName, policyName1, policyName2, description string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
+  name                    = %[1]q
+  description             = %[4]q
+  inline_policy_exclusive = false
+
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
@@ -1275,124 +4916,231 @@ resource "aws_iam_role" "test" {
     }]
   })
 
-  name                 = %[1]q
-  path                 = "/"
-  permissions_boundary = %[2]q
+  inline_policy {
+    name = %[2]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["ec2:Describe*"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
 }
-`, rName, permissionsBoundary)
+
+resource "aws_iam_role_policy" "test" {
+  name = %[3]q
+  role = aws_iam_role.test.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["s3:ListAllMyBuckets"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+`, roleName, policyName1, policyName2, description)
 }
 
-func testAccRoleConfig_basic(rName string) string {
+func testAccRoleConfig_policyInlineIdenticalDocuments(rName, action string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
+locals {
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = [%[2]q]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
 resource "aws_iam_role" "test" {
-  name = %[1]q
-  path = "/"
+  name_prefix = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
-      Effect = "Allow"
-      Sid    = ""
     }]
   })
+
+  inline_policy {
+    name   = "%[1]s-1"
+    policy = local.policy
+  }
+
+  inline_policy {
+    name   = "%[1]s-2"
+    policy = local.policy
+  }
 }
-`, rName)
+`, rName, action)
 }
 
-func testAccRoleConfig_diffs(rName, tags string) string {
+func testAccRoleConfig_policyInlineDuplicateNames(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
-resource "aws_iam_user" "user1" {
-  name = "%[1]s-baa204a2"
-  path = "/"
-}
+resource "aws_iam_role" "test" {
+  name = %[1]q
 
-resource "aws_iam_user" "user2" {
-  name = "%[1]s-fee06121"
-  path = "/"
-}
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
 
-resource "aws_iam_user" "user3" {
-  name = "%[1]s-2f0d132b"
-  path = "/"
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["ec2:Describe*"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+
+  inline_policy {
+    name = %[1]q
+
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["s3:ListAllMyBuckets"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
+}
+`, rName)
 }
 
-resource "aws_iam_user" "user4" {
-  name = "%[1]s-d1eaee06"
-  path = "/"
+func testAccCheckRoleAddAssumeRolePolicyStatement(ctx context.Context, role *iam.Role, sid string, condition map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		policy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
+		if err != nil {
+			return err
+		}
+
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+			return err
+		}
+
+		statement := map[string]interface{}{
+			"Sid":       sid,
+			"Effect":    "Allow",
+			"Action":    "sts:AssumeRole",
+			"Principal": map[string]interface{}{"Service": fmt.Sprintf("lambda.%s", acctest.PartitionDNSSuffix())},
+		}
+		if condition != nil {
+			statement["Condition"] = condition
+		}
+
+		statements, _ := doc["Statement"].([]interface{})
+		doc["Statement"] = append(statements, statement)
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.UpdateAssumeRolePolicyWithContext(ctx, &iam.UpdateAssumeRolePolicyInput{
+			PolicyDocument: aws.String(string(b)),
+			RoleName:       role.RoleName,
+		})
+
+		return err
+	}
 }
 
-resource "aws_iam_user" "user5" {
-  name = "%[1]s-d4a38c26"
-  path = "/"
+func testAccCheckRoleAssumeRolePolicyHasStatement(role *iam.Role, sid string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(policy, fmt.Sprintf("%q", sid)) {
+			return fmt.Errorf("assume role policy (%s) has no statement %q", policy, sid)
+		}
+
+		return nil
+	}
 }
 
+func testAccRoleConfig_assumeRolePolicyIgnoreStatements(rName, description string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
 resource "aws_iam_role" "test" {
-  name = %[1]q
-  path = "/"
+  name        = %[1]q
+  description = %[2]q
 
   assume_role_policy = jsonencode({
-    Id      = %[1]q
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole"
+      Action = "sts:AssumeRole",
       Principal = {
-        AWS = [
-          aws_iam_user.user1.arn,
-          aws_iam_user.user2.arn,
-          aws_iam_user.user3.arn,
-          aws_iam_user.user4.arn,
-          aws_iam_user.user5.arn,
-        ]
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
+      Sid    = "Managed"
     }]
   })
 
-  %[2]s
+  assume_role_policy_ignore_statements = ["ExternallyManaged"]
 }
-`, rName, tags)
+`, rName, description)
 }
 
-func testAccRoleConfig_diffsCondition(rName string) string {
-	return fmt.Sprintf(`
-data "aws_partition" "current" {}
+func testAccRoleARNImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
 
-resource "aws_iam_user" "user1" {
-  name = "%[1]s-cde2c453"
-  path = "/"
+		return rs.Primary.Attributes["arn"], nil
+	}
 }
 
+func testAccRoleConfig_path(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
 resource "aws_iam_role" "test" {
   name = %[1]q
-  path = "/"
+  path = "/tf-testing/nested/"
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRoleWithSAML"
-      Condition = {
-        IpAddress = {
-          "aws:SourceIp" = [
-            "0.0.0.0/0",
-          ]
-        }
-        StringEquals = {
-          "SAML:aud" = "https://signin.aws.amazon.com/saml"
-        }
-      }
+      Action = "sts:AssumeRole",
       Principal = {
-        AWS = [
-          aws_iam_user.user1.arn,
-        ]
+        Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
       Sid    = ""
@@ -1402,38 +5150,58 @@ resource "aws_iam_role" "test" {
 `, rName)
 }
 
-func testAccRoleConfig_description(rName string) string {
+func testAccRoleConfig_updateFailure(rName, description string, invalidBoundary bool) string {
 	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
   name        = %[1]q
-  description = "This 1s a D3scr!pti0n with weird content: &@90ë\"'{«¡Çø}"
-  path        = "/"
+  description = %[2]q
+
+  permissions_boundary = %[3]t ? "arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:policy/%[1]s-does-not-exist" : null
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
-      Effect = "Allow"
-      Sid    = ""
     }]
   })
+
+  tags = {
+    Description = %[2]q
+  }
 }
-`, rName)
+`, rName, description, invalidBoundary)
 }
 
-func testAccRoleConfig_updatedDescription(rName string) string {
+func testAccCheckRoleUpdateAssumeRolePolicyService(ctx context.Context, role *iam.Role, service string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"%s.%s"}}]}`, service, acctest.PartitionDNSSuffix())
+
+		_, err := conn.UpdateAssumeRolePolicyWithContext(ctx, &iam.UpdateAssumeRolePolicyInput{
+			PolicyDocument: aws.String(policy),
+			RoleName:       role.RoleName,
+		})
+
+		return err
+	}
+}
+
+func testAccRoleConfig_skipDestroy(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name        = %[1]q
-  description = "This 1s an Upd@ted D3scr!pti0n with weird content: &90ë\"'{«¡Çø}"
-  path        = "/"
+  name         = %[1]q
+  skip_destroy = true
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1450,58 +5218,89 @@ resource "aws_iam_role" "test" {
 `, rName)
 }
 
-func testAccRoleConfig_nameGenerated() string {
-	return `
+func testAccRoleConfig_trustedServices(rName, services string) string {
+	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  path = "/"
+  name             = %[1]q
+  trusted_services = [%[2]s]
+}
+`, rName, services)
+}
+
+func testAccRoleConfig_trustedServicesConflict(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name             = %[1]q
+  trusted_services = ["ec2.${data.aws_partition.current.dns_suffix}"]
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
-      Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-`
+`, rName)
 }
 
-func testAccRoleConfig_namePrefix(rName string) string {
+func testAccRoleConfig_assumeRolePolicyLiteralPercent(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
+data "aws_caller_identity" "current" {}
+
 resource "aws_iam_role" "test" {
-  name_prefix = %[1]q
-  path        = "/"
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        AWS = "arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:root"
+      }
+      Condition = {
+        StringLike = {
+          "sts:RoleSessionName" = "test-%%41+*"
+        }
       }
-      Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
 `, rName)
 }
 
-func testAccRoleConfig_pre(rName string) string {
+func testAccRoleConfig_managedPolicyNames(rName, path string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
-resource "aws_iam_role" "test" {
+resource "aws_iam_policy" "test" {
   name = %[1]q
-  path = "/test/"
+  path = %[2]q
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = "ec2:Describe*"
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
+}
+
+resource "aws_iam_role" "test" {
+  name                      = %[1]q
+  managed_policy_names      = [aws_iam_policy.test.name]
+  managed_policy_names_path = aws_iam_policy.test.path
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1511,47 +5310,20 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-
-resource "aws_iam_role_policy" "role_update_test" {
-  name = "%[1]s-2"
-  role = aws_iam_role.test.id
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Effect": "Allow",
-      "Action": [
-        "s3:GetBucketLocation",
-        "s3:ListAllMyBuckets"
-      ],
-      "Resource": "arn:${data.aws_partition.current.partition}:s3:::*"
-    }
-  ]
-}
-EOF
-}
-
-resource "aws_iam_instance_profile" "role_update_test" {
-  name = "%[1]s-2"
-  path = "/test/"
-  role = aws_iam_role.test.name
-}
-`, rName)
+`, rName, path)
 }
 
-func testAccRoleConfig_post(rName string) string {
+func testAccRoleConfig_managedPolicyNamesConflict(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
-  path = "/test/"
+  name                 = %[1]q
+  managed_policy_arns  = ["arn:${data.aws_partition.current.partition}:iam::aws:policy/ReadOnlyAccess"]
+  managed_policy_names = ["example"]
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1561,118 +5333,58 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-
-resource "aws_iam_role_policy" "role_update_test" {
-  name = "%[1]s-2"
-  role = aws_iam_role.test.id
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Effect": "Allow",
-      "Action": [
-        "s3:GetBucketLocation",
-        "s3:ListAllMyBuckets"
-      ],
-      "Resource": "arn:${data.aws_partition.current.partition}:s3:::*"
-    }
-  ]
-}
-EOF
-}
-
-resource "aws_iam_instance_profile" "role_update_test" {
-  name = "%[1]s-2"
-  path = "/test/"
-  role = aws_iam_role.test.name
-}
 `, rName)
 }
 
-func testAccRoleConfig_badJSON(rName string) string {
+func testAccRoleConfig_assumeRolePolicyHeredoc(rName, indent string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
   name = %[1]q
 
-  assume_role_policy = <<INTENTIONALLYBAD
+  assume_role_policy = <<EOF
 {
-  "Version": "2012-10-17",
-  "Statement": [
-  {
-    "Action": "sts:AssumeRole",
-    "Principal": {
-    "Service": "ec2.${data.aws_partition.current.dns_suffix}",
-    },
-    "Effect": "Allow",
-    "Sid": ""
-  }
-  ]
+%[2]s"Version": "2012-10-17",
+%[2]s"Statement": [
+%[2]s%[2]s{
+%[2]s%[2]s%[2]s"Action": "sts:AssumeRole",
+%[2]s%[2]s%[2]s"Effect": "Allow",
+%[2]s%[2]s%[2]s"Principal": {"Service": "ec2.${data.aws_partition.current.dns_suffix}"}
+%[2]s%[2]s}
+%[2]s]
 }
-INTENTIONALLYBAD
+EOF
 }
-`, rName)
+`, rName, indent)
 }
 
-func testAccRoleConfig_forceDetachPolicies(rName string) string {
+func testAccRoleConfig_descriptionOnOverflow(rName, onOverflow string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
-resource "aws_iam_role_policy" "test" {
-  name = %[1]q
-  role = aws_iam_role.test.id
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Action": [
-        "ec2:Describe*"
-      ],
-      "Effect": "Allow",
-      "Resource": "*"
-    }
-  ]
-}
-EOF
-}
-
 resource "aws_iam_policy" "test" {
-  name        = %[1]q
-  description = "A test policy"
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Action": [
-        "iam:ChangePassword"
-      ],
-      "Resource": "*",
-      "Effect": "Allow"
-    }
-  ]
-}
-EOF
-}
+  name = %[1]q
 
-resource "aws_iam_role_policy_attachment" "test" {
-  role       = aws_iam_role.test.name
-  policy_arn = aws_iam_policy.test.arn
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = "ec2:Describe*"
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
 }
 
 resource "aws_iam_role" "test" {
-  name                  = %[1]q
-  force_detach_policies = true
+  name = %[1]q
+
+  # The policy ID is only known at apply time, so the length is not validated during plan.
+  description             = "${aws_iam_policy.test.policy_id} ${join("", [for i in range(100) : "0123456789"])}"
+  description_on_overflow = %[2]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1682,19 +5394,19 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-`, rName)
+`, rName, onOverflow)
 }
 
-func testAccRoleConfig_tags(rName string) string {
+func testAccRoleConfig_descriptionValue(rName, description string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name        = %[1]q
+  description = %[2]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1704,24 +5416,31 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
-
-  tags = {
-    tag1 = "test-value1"
-    tag2 = "test-value2"
-  }
 }
-`, rName)
+`, rName, description)
+}
+
+func testAccCheckRoleNoTag(role *iam.Role, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, tag := range role.Tags {
+			if aws.StringValue(tag.Key) == key {
+				return fmt.Errorf("IAM Role tag (%q) found with value %q", key, aws.StringValue(tag.Value))
+			}
+		}
+
+		return nil
+	}
 }
 
-func testAccRoleConfig_tagsUpdate(rName string) string {
+func testAccRoleConfig_autoTagName(rName string, autoTagName bool, tagValue string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name          = %[1]q
+  auto_tag_name = %[2]t
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1731,23 +5450,24 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
   tags = {
-    tag2 = "test-value"
+    Key1 = %[3]q
   }
 }
-`, rName)
+`, rName, autoTagName, tagValue)
 }
 
-func testAccRoleConfig_policyInline(roleName, policyName string) string {
+func testAccRoleConfig_forbidExternalInlinePolicyDeletion(rName, policyName string, forbid bool) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name                                   = %[1]q
+  inline_policy_exclusive                = false
+  forbid_external_inline_policy_deletion = %[3]t
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1757,33 +5477,26 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
   inline_policy {
     name = %[2]q
 
-    policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Action": [
-        "ec2:Describe*"
-      ],
-      "Effect": "Allow",
-      "Resource": "*"
-    }
-  ]
-}
-EOF
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["ec2:Describe*"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
   }
 }
-`, roleName, policyName)
+`, rName, policyName, forbid)
 }
 
-func testAccRoleConfig_policyInlineUpdate(roleName, policyName2, policyName3 string) string {
+func testAccRoleConfig_assumeRolePolicyVersion(rName, version string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1791,64 +5504,51 @@ resource "aws_iam_role" "test" {
   name = %[1]q
 
   assume_role_policy = jsonencode({
-    Version = "2012-10-17"
+    Version = %[2]q
     Statement = [{
       Action = "sts:AssumeRole",
       Principal = {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
+}
+`, rName, version)
+}
 
-  inline_policy {
-    name = %[2]q
+func testAccRoleConfig_assumeRolePolicyDocumentSessionTagKeys(rName, sessionTagKeys string) string {
+	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
 
-    policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Action": [
-        "ec2:Describe*"
-      ],
-      "Effect": "Allow",
-      "Resource": "*"
-    }
-  ]
-}
-EOF
-  }
+data "aws_partition" "current" {}
 
-  inline_policy {
-    name = %[3]q
+resource "aws_iam_role" "test" {
+  name = %[1]q
 
-    policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-      "Action": [
-        "ec2:Describe*"
-      ],
-      "Effect": "Allow",
-      "Resource": "*"
+  assume_role_policy_document {
+    session_tag_keys = [%[2]s]
+
+    statement {
+      actions = ["sts:AssumeRole"]
+
+      principals {
+        type        = "AWS"
+        identifiers = ["arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:root"]
+      }
     }
-  ]
-}
-EOF
   }
 }
-`, roleName, policyName2, policyName3)
+`, rName, sessionTagKeys)
 }
 
-func testAccRoleConfig_policyInlineUpdateDown(roleName, policyName3 string) string {
+func testAccRoleConfig_pathValue(rName, path string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
   name = %[1]q
+  path = %[2]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -1858,33 +5558,13 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
-
-  inline_policy {
-    name = %[2]q
-
-    policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": {
-    "Effect": "Allow",
-    "Action": "ec2:Describe*",
-    "Resource": "*",
-    "Condition": {
-      "DateGreaterThan": {"aws:CurrentTime": "2017-07-01T00:00:00Z"},
-      "DateLessThan": {"aws:CurrentTime": "2017-12-31T23:59:59Z"}
-    }
-  }
-}
-EOF
-  }
 }
-`, roleName, policyName3)
+`, rName, path)
 }
 
-func testAccRoleConfig_policyInlineActionOrder(roleName string) string {
+func testAccRoleConfig_inlinePolicies(rName, policies string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1899,32 +5579,17 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
-  inline_policy {
-    name = %[1]q
-
-    policy = jsonencode({
-      Version = "2012-10-17"
-      Statement = [{
-        Action = [
-          "ec2:DescribeScheduledInstances",
-          "ec2:DescribeScheduledInstanceAvailability",
-          "ec2:DescribeFastSnapshotRestores",
-          "ec2:DescribeElasticGpus",
-        ]
-        Effect   = "Allow"
-        Resource = "*"
-      }]
-    })
+  inline_policies = {
+%[2]s
   }
 }
-`, roleName)
+`, rName, policies)
 }
 
-func testAccRoleConfig_policyInlineActionOrderActualDiff(roleName string) string {
+func testAccRoleConfig_inlinePoliciesConflict(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1939,31 +5604,28 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
-  inline_policy {
-    name = %[1]q
+  inline_policies = {
+    read = jsonencode({
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "ec2:Describe*", Resource = "*" }]
+    })
+  }
 
+  inline_policy {
+    name = "list"
     policy = jsonencode({
-      Version = "2012-10-17"
-      Statement = [{
-        Action = [
-          "ec2:DescribeScheduledInstances",
-          "ec2:DescribeElasticGpus",
-          "ec2:DescribeScheduledInstanceAvailability",
-        ]
-        Effect   = "Allow"
-        Resource = "*"
-      }]
+      Version   = "2012-10-17"
+      Statement = [{ Effect = "Allow", Action = "s3:ListAllMyBuckets", Resource = "*" }]
     })
   }
 }
-`, roleName)
+`, rName)
 }
 
-func testAccRoleConfig_policyInlineActionNewOrder(roleName string) string {
+func testAccRoleConfig_ignoreTagPrefixes(rName, prefix string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1973,329 +5635,255 @@ resource "aws_iam_role" "test" {
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
-  inline_policy {
-    name = %[1]q
+  ignore_tag_prefixes = [%[2]q]
 
-    policy = jsonencode({
-      Version = "2012-10-17"
-      Statement = [{
-        Action = [
-          "ec2:DescribeElasticGpus",
-          "ec2:DescribeScheduledInstances",
-          "ec2:DescribeFastSnapshotRestores",
-          "ec2:DescribeScheduledInstanceAvailability",
-        ]
-        Effect   = "Allow"
-        Resource = "*"
-      }]
-    })
+  tags = {
+    key1 = "value1"
   }
 }
-`, roleName)
+`, rName, prefix)
 }
 
-func testAccRoleConfig_policyManaged(roleName, policyName string) string {
+func testAccRoleConfig_assumeRolePolicyWaitForPropagation(rName, service string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
-resource "aws_iam_policy" "test" {
-  name = %[1]q
-  path = "/tf-testing/"
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
-}
-
 resource "aws_iam_role" "test" {
-  name                = %[2]q
-  managed_policy_arns = [aws_iam_policy.test.arn]
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "%[2]s.${data.aws_partition.current.dns_suffix}"
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
-}
-`, policyName, roleName)
-}
-
-func testAccRoleConfig_policyManagedUpdate(roleName, policyName1, policyName2, policyName3 string) string {
-	return fmt.Sprintf(`
-data "aws_partition" "current" {}
-
-resource "aws_iam_policy" "test" {
-  name = %[1]q
-  path = "/tf-testing/"
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
-}
-
-resource "aws_iam_policy" "test2" {
-  name = %[2]q
-  path = "/tf-testing/"
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
+  assume_role_policy_wait_for_propagation = true
 }
-EOF
-}
-
-resource "aws_iam_policy" "test3" {
-  name = %[3]q
-  path = "/tf-testing/"
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
+`, rName, service)
 }
-EOF
+
+func testAccCheckRoleInstanceProfileNotExists(ctx context.Context, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).IAMConn(ctx)
+
+		_, err := tfiam.FindInstanceProfileByName(ctx, conn, name)
+
+		if tfresource.NotFound(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("IAM Instance Profile %s still exists", name)
+	}
 }
 
+func testAccRoleConfig_createInstanceProfile(rName string, createInstanceProfile bool) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
 resource "aws_iam_role" "test" {
-  name                = %[4]q
-  managed_policy_arns = [aws_iam_policy.test2.arn, aws_iam_policy.test3.arn]
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
+
+  create_instance_profile = %[2]t
 }
-`, policyName1, policyName2, policyName3, roleName)
+`, rName, createInstanceProfile)
 }
 
-func testAccRoleConfig_policyManagedUpdateDown(roleName, policyName1, policyName2, policyName3 string) string {
+func testAccRoleConfig_deletionProtectionTag(rName string) string {
 	return fmt.Sprintf(`
+provider "aws" {
+  iam_role_deletion_protection_tag = "terraform:protected"
+}
+
 data "aws_partition" "current" {}
 
-resource "aws_iam_policy" "test" {
+resource "aws_iam_role" "test" {
   name = %[1]q
-  path = "/tf-testing/"
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+      Effect = "Allow"
+    }]
+  })
 }
-EOF
+`, rName)
 }
 
-resource "aws_iam_policy" "test2" {
-  name = %[2]q
-  path = "/tf-testing/"
+func testAccRoleConfig_computeBoundaryAnalysis(rName string, computeBoundaryAnalysis bool) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
+resource "aws_iam_policy" "boundary" {
+  name = "%[1]s-boundary"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["s3:Get*", "s3:List*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
 }
 
-resource "aws_iam_policy" "test3" {
-  name = %[3]q
-  path = "/tf-testing/"
+resource "aws_iam_policy" "narrow" {
+  name = "%[1]s-narrow"
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = "s3:GetObject"
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
 }
-EOF
+
+resource "aws_iam_policy" "broad" {
+  name = "%[1]s-broad"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["s3:*", "ec2:Describe*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
 }
 
 resource "aws_iam_role" "test" {
-  name                = %[4]q
-  managed_policy_arns = [aws_iam_policy.test3.arn]
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
+
+  permissions_boundary      = aws_iam_policy.boundary.arn
+  managed_policy_arns       = [aws_iam_policy.narrow.arn, aws_iam_policy.broad.arn]
+  compute_boundary_analysis = %[2]t
 }
-`, policyName1, policyName2, policyName3, roleName)
+`, rName, computeBoundaryAnalysis)
 }
 
-func testAccRoleConfig_policyExtraManaged(roleName, policyName1, policyName2 string) string {
+func testAccRoleConfig_assumeRolePolicyDocumentConditions(rName string) string {
 	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
 data "aws_partition" "current" {}
 
-resource "aws_iam_policy" "test" {
+resource "aws_iam_role" "test" {
   name = %[1]q
-  path = "/tf-testing/"
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
+  assume_role_policy_document {
+    statement {
+      actions = ["sts:AssumeRole"]
+
+      principals {
+        type        = "AWS"
+        identifiers = ["arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:root"]
+      }
+
+      condition {
+        test     = "StringEquals"
+        variable = "sts:ExternalId"
+        values   = [%[1]q]
+      }
+
+      condition {
+        test     = "StringEquals"
+        variable = "aws:SourceAccount"
+        values   = [data.aws_caller_identity.current.account_id]
+      }
     }
-  ]
+  }
 }
-EOF
+`, rName)
 }
 
-resource "aws_iam_policy" "test2" {
-  name = %[2]q
-  path = "/tf-testing/"
+func testAccRoleConfig_computeBoundaryDocument(rName string, computeBoundaryDocument bool) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
+resource "aws_iam_policy" "boundary" {
+  name = "%[1]s-boundary"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action   = ["s3:Get*", "s3:List*"]
+      Effect   = "Allow"
+      Resource = "*"
+    }]
+  })
 }
 
 resource "aws_iam_role" "test" {
-  name                = %[3]q
-  managed_policy_arns = [aws_iam_policy.test.arn]
+  name = %[1]q
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [{
-      Action = "sts:AssumeRole",
+      Action = "sts:AssumeRole"
       Principal = {
-        Service = "ec2.${data.aws_partition.current.dns_suffix}",
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
-}
-`, policyName1, policyName2, roleName)
-}
 
-func testAccRolePolicyExtraInlineConfig() string {
-	return `{
-	"Version": "2012-10-17",
-	"Statement": [
-		{
-		"Action": [
-			"ec2:Describe*"
-		],
-		"Effect": "Allow",
-		"Resource": "*"
-		}
-	]
-}`
+  permissions_boundary      = aws_iam_policy.boundary.arn
+  compute_boundary_document = %[2]t
+}
+`, rName, computeBoundaryDocument)
 }
 
-func testAccRoleConfig_policyNoInline(roleName string) string {
+func testAccRoleConfig_adoptExternalTags(rName, tagValue string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name                = %[1]q
+  adopt_external_tags = true
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -2305,19 +5893,24 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
+
+  tags = {
+    Key1 = %[2]q
+  }
 }
-`, roleName)
+`, rName, tagValue)
 }
 
-func testAccRoleConfig_policyNoManaged(roleName, policyName string) string {
+func testAccRoleConfig_validatePermissionsBoundary(rName, boundaryName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name                          = %[1]q
+  permissions_boundary          = "arn:${data.aws_partition.current.partition}:iam::aws:policy/%[2]s"
+  validate_permissions_boundary = true
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -2327,39 +5920,19 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 }
-
-resource "aws_iam_policy" "managed-policy1" {
-  name = %[2]q
-  path = "/tf-testing/"
-
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
-}
-`, roleName, policyName)
+`, rName, boundaryName)
 }
 
-func testAccRoleConfig_policyEmptyInline(roleName string) string {
+func testAccRoleConfig_normalizeInlinePolicies(rName, policies string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name                      = %[1]q
+  normalize_inline_policies = true
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -2369,21 +5942,25 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
-  inline_policy {}
+  inline_policies = {
+%[2]s
+  }
 }
-`, roleName)
+`, rName, policies)
 }
 
-func testAccRoleConfig_policyEmptyManaged(roleName, policyName string) string {
+func testAccRoleConfig_destroyManagedInlinePoliciesOnly(rName, policyName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
 resource "aws_iam_role" "test" {
-  name = %[1]q
+  name                                 = %[1]q
+  force_detach_policies                = true
+  inline_policy_exclusive              = false
+  destroy_managed_inline_policies_only = true
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -2393,31 +5970,21 @@ resource "aws_iam_role" "test" {
         Service = "ec2.${data.aws_partition.current.dns_suffix}",
       }
       Effect = "Allow"
-      Sid    = ""
     }]
   })
 
-  managed_policy_arns = []
-}
-
-resource "aws_iam_policy" "managed-policy1" {
-  name = %[2]q
-  path = "/tf-testing/"
+  inline_policy {
+    name = %[2]q
 
-  policy = <<EOF
-{
-  "Version": "2012-10-17",
-  "Statement": [
-    {
-    "Action": [
-      "ec2:Describe*"
-    ],
-    "Effect": "Allow",
-    "Resource": "*"
-    }
-  ]
-}
-EOF
+    policy = jsonencode({
+      Version = "2012-10-17"
+      Statement = [{
+        Action   = ["ec2:Describe*"]
+        Effect   = "Allow"
+        Resource = "*"
+      }]
+    })
+  }
 }
-`, roleName, policyName)
+`, rName, policyName)
 }
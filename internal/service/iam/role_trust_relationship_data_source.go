@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// @SDKDataSource("aws_iam_role_trust_relationship")
+func DataSourceRoleTrustRelationship() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceRoleTrustRelationshipRead,
+
+		Schema: map[string]*schema.Schema{
+			"assume_role_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"statement": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"actions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"conditions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"test": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"variable": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"effect": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"principals": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"identifiers": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"sid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRoleTrustRelationshipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).IAMConn(ctx)
+
+	roleName := d.Get("role_name").(string)
+	role, err := FindRoleByName(ctx, conn, roleName)
+
+	if tfresource.NotFound(err) {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) trust relationship: IAM Role not found, check that role_name is correct", roleName)
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s): %s", roleName, err)
+	}
+
+	assumeRolePolicy, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) trust relationship: %s", roleName, err)
+	}
+
+	statements, err := flattenRoleTrustRelationshipStatements(assumeRolePolicy)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IAM Role (%s) trust relationship: %s", roleName, err)
+	}
+
+	d.SetId(aws.StringValue(role.RoleName))
+	d.Set("assume_role_policy", assumeRolePolicy)
+	if err := d.Set("statement", statements); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting statement: %s", err)
+	}
+
+	return diags
+}
+
+// flattenRoleTrustRelationshipStatements parses a trust policy into one element per statement.
+// Principal identifiers and condition values are always returned as lists, whether the document uses a
+// single string or an array. Principals are sorted by type and conditions by test and variable, as the
+// document's JSON objects are unordered.
+func flattenRoleTrustRelationshipStatements(policy string) ([]interface{}, error) {
+	var doc struct {
+		Statement json.RawMessage
+	}
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, fmt.Errorf("parsing assume role policy: %w", err)
+	}
+
+	// A policy with a single statement may specify it as an object rather than an array.
+	rawStatements := bytes.TrimSpace(doc.Statement)
+	if bytes.HasPrefix(rawStatements, []byte("{")) {
+		rawStatements = append(append([]byte("["), rawStatements...), ']')
+	}
+
+	var statements []*IAMPolicyStatement
+
+	if len(rawStatements) > 0 {
+		if err := json.Unmarshal(rawStatements, &statements); err != nil {
+			return nil, fmt.Errorf("parsing assume role policy statements: %w", err)
+		}
+	}
+
+	tfList := make([]interface{}, 0, len(statements))
+
+	for _, statement := range statements {
+		if statement == nil {
+			continue
+		}
+
+		principals := make([]interface{}, 0, len(statement.Principals))
+		sort.SliceStable(statement.Principals, func(i, j int) bool {
+			return statement.Principals[i].Type < statement.Principals[j].Type
+		})
+		for _, principal := range statement.Principals {
+			principals = append(principals, map[string]interface{}{
+				"identifiers": policyStatementValues(principal.Identifiers),
+				"type":        principal.Type,
+			})
+		}
+
+		conditions := make([]interface{}, 0, len(statement.Conditions))
+		sort.SliceStable(statement.Conditions, func(i, j int) bool {
+			if statement.Conditions[i].Test != statement.Conditions[j].Test {
+				return statement.Conditions[i].Test < statement.Conditions[j].Test
+			}
+			return statement.Conditions[i].Variable < statement.Conditions[j].Variable
+		})
+		for _, condition := range statement.Conditions {
+			conditions = append(conditions, map[string]interface{}{
+				"test":     condition.Test,
+				"values":   policyStatementValues(condition.Values),
+				"variable": condition.Variable,
+			})
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"actions":    policyStatementValues(statement.Actions),
+			"conditions": conditions,
+			"effect":     statement.Effect,
+			"principals": principals,
+			"sid":        statement.Sid,
+		})
+	}
+
+	return tfList, nil
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfiam "github.com/hashicorp/terraform-provider-aws/internal/service/iam"
+)
+
+func TestFlattenRoleTrustRelationshipStatements(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy  string
+		want    []interface{}
+		wantErr bool
+	}{
+		"single statement object": {
+			policy: `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}}`,
+			want: []interface{}{
+				map[string]interface{}{
+					"actions":    []string{"sts:AssumeRole"},
+					"conditions": []interface{}{},
+					"effect":     "Allow",
+					"principals": []interface{}{
+						map[string]interface{}{"identifiers": []string{"ec2.amazonaws.com"}, "type": "Service"},
+					},
+					"sid": "",
+				},
+			},
+		},
+		"multiple statements": {
+			policy: `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "EC2",
+      "Effect": "Allow",
+      "Action": "sts:AssumeRole",
+      "Principal": {"Service": ["ec2.amazonaws.com", "lambda.amazonaws.com"]}
+    },
+    {
+      "Sid": "CrossAccount",
+      "Effect": "Allow",
+      "Action": ["sts:AssumeRole", "sts:TagSession"],
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root", "Federated": ["cognito-identity.amazonaws.com"]},
+      "Condition": {
+        "StringEquals": {"sts:ExternalId": "secret", "aws:PrincipalTag/team": ["a", "b"]},
+        "Bool": {"aws:MultiFactorAuthPresent": true}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "Action": "sts:AssumeRole",
+      "Principal": "*"
+    }
+  ]
+}`,
+			want: []interface{}{
+				map[string]interface{}{
+					"actions":    []string{"sts:AssumeRole"},
+					"conditions": []interface{}{},
+					"effect":     "Allow",
+					"principals": []interface{}{
+						map[string]interface{}{"identifiers": []string{"ec2.amazonaws.com", "lambda.amazonaws.com"}, "type": "Service"},
+					},
+					"sid": "EC2",
+				},
+				map[string]interface{}{
+					"actions": []string{"sts:AssumeRole", "sts:TagSession"},
+					"conditions": []interface{}{
+						map[string]interface{}{"test": "Bool", "values": []string{"true"}, "variable": "aws:MultiFactorAuthPresent"},
+						map[string]interface{}{"test": "StringEquals", "values": []string{"a", "b"}, "variable": "aws:PrincipalTag/team"},
+						map[string]interface{}{"test": "StringEquals", "values": []string{"secret"}, "variable": "sts:ExternalId"},
+					},
+					"effect": "Allow",
+					"principals": []interface{}{
+						map[string]interface{}{"identifiers": []string{"arn:aws:iam::123456789012:root"}, "type": "AWS"}, // lintignore:AWSAT005
+						map[string]interface{}{"identifiers": []string{"cognito-identity.amazonaws.com"}, "type": "Federated"},
+					},
+					"sid": "CrossAccount",
+				},
+				map[string]interface{}{
+					"actions":    []string{"sts:AssumeRole"},
+					"conditions": []interface{}{},
+					"effect":     "Deny",
+					"principals": []interface{}{
+						map[string]interface{}{"identifiers": []string{"*"}, "type": "*"},
+					},
+					"sid": "",
+				},
+			},
+		},
+		"no statements": {
+			policy: `{"Version":"2012-10-17"}`,
+			want:   []interface{}{},
+		},
+		"invalid JSON": {
+			policy:  `{`,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfiam.FlattenRoleTrustRelationshipStatements(testCase.policy)
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %#v, want %#v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestAccIAMRoleTrustRelationshipDataSource_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_iam_role_trust_relationship.test"
+	resourceName := "aws_iam_role.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleTrustRelationshipDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "assume_role_policy"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.sid", "EC2"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.effect", "Allow"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.actions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.actions.0", "sts:AssumeRole"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.principals.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.principals.0.type", "Service"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.principals.0.identifiers.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.0.conditions.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.sid", "CrossAccount"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.actions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.principals.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.principals.0.type", "AWS"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.conditions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.conditions.0.test", "StringEquals"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.conditions.0.variable", "sts:ExternalId"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.conditions.0.values.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "statement.1.conditions.0.values.0", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRoleTrustRelationshipDataSource_nonExistent(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleTrustRelationshipDataSourceConfig_nonExistent(rName),
+				ExpectError: regexp.MustCompile(`IAM Role not found`),
+			},
+		},
+	})
+}
+
+func testAccRoleTrustRelationshipDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "EC2"
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "ec2.${data.aws_partition.current.dns_suffix}"
+        }
+      },
+      {
+        Sid    = "CrossAccount"
+        Action = ["sts:AssumeRole", "sts:TagSession"]
+        Effect = "Allow"
+        Principal = {
+          AWS = ["arn:${data.aws_partition.current.partition}:iam::${data.aws_caller_identity.current.account_id}:root"]
+        }
+        Condition = {
+          StringEquals = {
+            "sts:ExternalId" = %[1]q
+          }
+        }
+      },
+    ]
+  })
+}
+
+data "aws_iam_role_trust_relationship" "test" {
+  role_name = aws_iam_role.test.name
+}
+`, rName)
+}
+
+func testAccRoleTrustRelationshipDataSourceConfig_nonExistent(rName string) string {
+	return fmt.Sprintf(`
+data "aws_iam_role_trust_relationship" "test" {
+  role_name = %[1]q
+}
+`, rName)
+}
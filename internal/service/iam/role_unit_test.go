@@ -0,0 +1,3653 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	awspolicy "github.com/hashicorp/awspolicyequivalence"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfiam "github.com/hashicorp/terraform-provider-aws/internal/service/iam"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"golang.org/x/exp/slices"
+)
+
+// newMockIAMConn returns an IAM connection that sends no requests. Each call is passed to the handler for its
+// operation, which can set r.Data and r.Error. A call to an operation without a handler fails the test.
+func newMockIAMConn(t testing.TB, handlers map[string]func(*request.Request)) *iam.IAM {
+	t.Helper()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("creating session: %s", err)
+	}
+
+	conn := iam.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		handler, ok := handlers[r.Operation.Name]
+		if !ok {
+			t.Errorf("unexpected %s call", r.Operation.Name)
+			return
+		}
+
+		handler(r)
+	})
+
+	return conn
+}
+
+func TestDecodeRoleAssumeRolePolicy(t *testing.T) {
+	t.Parallel()
+
+	// The condition value contains a literal "%" followed by hex digits, and a "+".
+	const policy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sts:AssumeRole","Condition":{"StringLike":{"aws:userid":"AIDA%41+*"}}}]}`
+
+	testCases := map[string]struct {
+		value string
+		want  string
+	}{
+		"URL-encoded": {
+			value: url.QueryEscape(policy),
+			want:  policy,
+		},
+		"JSON": {
+			value: policy,
+			want:  policy,
+		},
+		"JSON with leading whitespace": {
+			value: "\n  " + policy,
+			want:  "\n  " + policy,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfiam.DecodeRoleAssumeRolePolicy(testCase.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRoleNameFromARN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		arn         string
+		want        string
+		expectError bool
+	}{
+		{
+			arn:  "arn:aws:iam::123456789012:role/name", // lintignore:AWSAT005
+			want: "name",
+		},
+		{
+			arn:  "arn:aws:iam::123456789012:role/path/name", // lintignore:AWSAT005
+			want: "name",
+		},
+		{
+			arn:  "arn:aws-us-gov:iam::123456789012:role/path/nested/name", // lintignore:AWSAT005
+			want: "name",
+		},
+		{
+			arn:         "arn:aws:iam::123456789012:user/name", // lintignore:AWSAT005
+			expectError: true,
+		},
+		{
+			arn:         "arn:aws:s3:::role/name", // lintignore:AWSAT005
+			expectError: true,
+		},
+		{
+			arn:         "arn:aws:iam::123456789012:role/path/", // lintignore:AWSAT005
+			expectError: true,
+		},
+		{
+			arn:         "arn:aws:iam",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.arn, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfiam.RoleNameFromARN(testCase.arn)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got role name %q", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseRoleARN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		arn         string
+		wantPath    string
+		wantName    string
+		expectError bool
+	}{
+		{
+			arn:      "arn:aws:iam::123456789012:role/name", // lintignore:AWSAT005
+			wantPath: "/",
+			wantName: "name",
+		},
+		{
+			arn:      "arn:aws:iam::123456789012:role/path/name", // lintignore:AWSAT005
+			wantPath: "/path/",
+			wantName: "name",
+		},
+		{
+			arn:      "arn:aws-us-gov:iam::123456789012:role/path/nested/name", // lintignore:AWSAT005
+			wantPath: "/path/nested/",
+			wantName: "name",
+		},
+		{
+			arn:         "arn:aws:iam::123456789012:user/path/name", // lintignore:AWSAT005
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.arn, func(t *testing.T) {
+			t.Parallel()
+
+			gotPath, gotName, err := tfiam.ParseRoleARN(testCase.arn)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("expected error, got role path %q and name %q", gotPath, gotName)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if gotPath != testCase.wantPath {
+				t.Errorf("got path %q, want %q", gotPath, testCase.wantPath)
+			}
+
+			if gotName != testCase.wantName {
+				t.Errorf("got name %q, want %q", gotName, testCase.wantName)
+			}
+		})
+	}
+}
+
+func TestRoleIsServiceLinked(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]bool{
+		"/":                            false,
+		"/tf-testing/nested/":          false,
+		"/aws-service-role-lookalike/": false,
+		"/aws-service-role/":           true,
+		"/aws-service-role/autoscaling.amazonaws.com/": true,
+	}
+
+	for path, want := range testCases {
+		if got := tfiam.RoleIsServiceLinked(path); got != want {
+			t.Errorf("RoleIsServiceLinked(%q) = %t, want %t", path, got, want)
+		}
+	}
+}
+
+func TestRolePathStateFunc(t *testing.T) {
+	t.Parallel()
+
+	stateFunc := tfiam.ResourceRole().SchemaMap()["path"].StateFunc
+
+	testCases := map[string]string{
+		"/team":  "/team/",
+		"/team/": "/team/",
+		"/":      "/",
+	}
+
+	for path, want := range testCases {
+		if got := stateFunc(path); got != want {
+			t.Errorf("%q: got %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRoleAssumeRolePolicyStateFunc(t *testing.T) {
+	t.Parallel()
+
+	stateFunc := tfiam.ResourceRole().SchemaMap()["assume_role_policy"].StateFunc
+
+	const want = `{"Statement":[{"Action":"sts:AssumeRole","Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"}}],"Version":"2012-10-17"}`
+
+	for _, policy := range []string{
+		want,
+		`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "ec2.amazonaws.com"},
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+`,
+		"{\"Version\":\"2012-10-17\",\t\"Statement\":[{\"Action\":\"sts:AssumeRole\",\"Effect\":\"Allow\",\"Principal\":{\"Service\":\"ec2.amazonaws.com\"}}]}\r\n",
+	} {
+		if got := stateFunc(policy); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestExpandRoleDescription(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("é", 1001)
+
+	testCases := map[string]struct {
+		description string
+		onOverflow  string
+		want        string
+		expectError bool
+	}{
+		"within limit": {
+			description: strings.Repeat("é", 1000),
+			onOverflow:  "error",
+			want:        strings.Repeat("é", 1000),
+		},
+		"overflow error": {
+			description: long,
+			onOverflow:  "error",
+			expectError: true,
+		},
+		"overflow truncate": {
+			description: long,
+			onOverflow:  "truncate",
+			want:        strings.Repeat("é", 1000),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfiam.ExpandRoleDescription(testCase.description, testCase.onOverflow)
+
+			if testCase.expectError {
+				if err == nil || !strings.Contains(err.Error(), "description is 1001 characters") {
+					t.Errorf("expected over-length error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %d characters, want %d", len([]rune(got)), len([]rune(testCase.want)))
+			}
+		})
+	}
+}
+
+func TestRoleDescriptionDiffSuppress(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 1200)
+	suppress := tfiam.ResourceRole().SchemaMap()["description"].DiffSuppressFunc
+
+	for _, testCase := range []struct {
+		onOverflow string
+		old, new   string
+		want       bool
+	}{
+		{onOverflow: "truncate", old: long[:1000], new: long, want: true},
+		{onOverflow: "truncate", old: long[:999], new: long},
+		{onOverflow: "truncate", old: "before", new: "after"},
+		{onOverflow: "error", old: long[:1000], new: long},
+	} {
+		d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().SchemaMap(), map[string]interface{}{
+			"description_on_overflow": testCase.onOverflow,
+		})
+
+		if got := suppress("description", testCase.old, testCase.new, d); got != testCase.want {
+			t.Errorf("description_on_overflow %s, old %d and new %d characters: got %t, want %t", testCase.onOverflow, len(testCase.old), len(testCase.new), got, testCase.want)
+		}
+	}
+}
+
+func TestSplitRoleAssumeRolePolicyStatements(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		policy      string
+		sids        []string
+		wantPolicy  string
+		wantRemoved int
+	}{
+		{
+			name:        "no match",
+			policy:      `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`,
+			sids:        []string{"B"},
+			wantPolicy:  `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`,
+			wantRemoved: 0,
+		},
+		{
+			name:        "match",
+			policy:      `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}},{"Sid":"B","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"lambda.amazonaws.com"}}]}`,
+			sids:        []string{"B"},
+			wantPolicy:  `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`,
+			wantRemoved: 1,
+		},
+		{
+			name:        "single statement object",
+			policy:      `{"Version":"2012-10-17","Statement":{"Sid":"B","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}}`,
+			sids:        []string{"B"},
+			wantPolicy:  `{"Version":"2012-10-17","Statement":[]}`,
+			wantRemoved: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotPolicy, gotRemoved, err := tfiam.SplitRoleAssumeRolePolicyStatements(testCase.policy, testCase.sids)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if equivalent, err := awspolicy.PoliciesAreEquivalent(gotPolicy, testCase.wantPolicy); err != nil || !equivalent {
+				t.Errorf("got policy %s, want %s", gotPolicy, testCase.wantPolicy)
+			}
+
+			if got, want := len(gotRemoved), testCase.wantRemoved; got != want {
+				t.Errorf("got %d removed statements, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeRoleAssumeRolePolicyStatements(t *testing.T) {
+	t.Parallel()
+
+	policy := `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`
+	current := `{"Version":"2012-10-17","Statement":[{"Sid":"Old","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ecs.amazonaws.com"}},{"Sid":"B","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"lambda.amazonaws.com"}}]}`
+	want := `{"Version":"2012-10-17","Statement":[{"Sid":"A","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}},{"Sid":"B","Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"lambda.amazonaws.com"}}]}`
+
+	got, err := tfiam.MergeRoleAssumeRolePolicyStatements(policy, current, []string{"B"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if equivalent, err := awspolicy.PoliciesAreEquivalent(got, want); err != nil || !equivalent {
+		t.Errorf("got policy %s, want %s", got, want)
+	}
+}
+
+func TestRoleNamePrefixLength(t *testing.T) {
+	t.Parallel()
+
+	validateFunc := tfiam.ResourceRole().Schema["name_prefix"].ValidateFunc
+
+	testCases := []struct {
+		prefix      string
+		expectError bool
+	}{
+		{
+			prefix: strings.Repeat("a", 32),
+		},
+		{
+			// 38 + 26 character unique suffix = 64 character role name.
+			prefix: strings.Repeat("a", 38),
+		},
+		{
+			prefix:      strings.Repeat("a", 39),
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(strconv.Itoa(len(testCase.prefix)), func(t *testing.T) {
+			t.Parallel()
+
+			_, errs := validateFunc(testCase.prefix, "name_prefix")
+
+			if got := len(errs) > 0; got != testCase.expectError {
+				t.Errorf("name_prefix of length %d: got errors %v, expected error: %t", len(testCase.prefix), errs, testCase.expectError)
+			}
+
+			if !testCase.expectError {
+				if got, max := len(id.PrefixedUniqueId(testCase.prefix)), 64; got > max {
+					t.Errorf("generated name length %d exceeds %d", got, max)
+				}
+			}
+		})
+	}
+}
+
+func TestRoleHasTagKeys(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		role *iam.Role
+		tags []*iam.Tag
+		want bool
+	}{
+		{
+			name: "no tags",
+			role: &iam.Role{},
+			want: true,
+		},
+		{
+			name: "not yet returned",
+			role: &iam.Role{},
+			tags: []*iam.Tag{{Key: aws.String("k1"), Value: aws.String("v1")}},
+			want: false,
+		},
+		{
+			name: "partially returned",
+			role: &iam.Role{Tags: []*iam.Tag{{Key: aws.String("k1"), Value: aws.String("v1")}}},
+			tags: []*iam.Tag{{Key: aws.String("k1"), Value: aws.String("v1")}, {Key: aws.String("k2"), Value: aws.String("v2")}},
+			want: false,
+		},
+		{
+			name: "returned",
+			role: &iam.Role{Tags: []*iam.Tag{{Key: aws.String("k2"), Value: aws.String("v2")}, {Key: aws.String("k1"), Value: aws.String("v1")}}},
+			tags: []*iam.Tag{{Key: aws.String("k1"), Value: aws.String("v1")}, {Key: aws.String("k2"), Value: aws.String("v2")}},
+			want: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RoleHasTagKeys(testCase.role, testCase.tags); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCheckRolePermissionsBoundaryExists(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		getErr        error
+		expectedError *regexp.Regexp
+	}{
+		"exists": {},
+		"missing": {
+			getErr:        awserr.New(iam.ErrCodeNoSuchEntityException, "Policy arn:aws:iam::123456789012:policy/boundary was not found.", nil), // lintignore:AWSAT005
+			expectedError: regexp.MustCompile(`permissions_boundary \(.+\) does not exist; create the policy before the role`),
+		},
+		"access denied": {
+			getErr:        awserr.New("AccessDenied", "User is not authorized to perform: iam:GetPolicy", nil),
+			expectedError: regexp.MustCompile(`reading permissions_boundary \(.+\): AccessDenied`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetPolicy": func(r *request.Request) {
+					r.Data.(*iam.GetPolicyOutput).Policy = &iam.Policy{Arn: r.Params.(*iam.GetPolicyInput).PolicyArn}
+					r.Error = testCase.getErr
+				},
+			})
+
+			err := tfiam.CheckRolePermissionsBoundaryExists(ctx, conn, "arn:aws:iam::123456789012:policy/boundary") // lintignore:AWSAT005
+
+			if testCase.expectedError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !testCase.expectedError.MatchString(err.Error()) {
+				t.Fatalf("got error %v, want match for %s", err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestExpandRoleAssumeRolePolicyDocument(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().Schema, map[string]interface{}{
+		"assume_role_policy_document": []interface{}{
+			map[string]interface{}{
+				"statement": []interface{}{
+					map[string]interface{}{
+						"actions": []interface{}{"sts:AssumeRole"},
+						"principals": []interface{}{
+							map[string]interface{}{
+								"type":        "Service",
+								"identifiers": []interface{}{"ec2.amazonaws.com"},
+							},
+						},
+						"condition": []interface{}{
+							map[string]interface{}{
+								"test":     "StringEquals",
+								"variable": "aws:PrincipalOrgID",
+								"values":   []interface{}{"o-1234567890"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	got, err := tfiam.ExpandRoleAssumeRolePolicy(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "sts:AssumeRole",
+    "Principal": {"Service": "ec2.amazonaws.com"},
+    "Condition": {"StringEquals": {"aws:PrincipalOrgID": "o-1234567890"}}
+  }]
+}`
+
+	equivalent, err := awspolicy.PoliciesAreEquivalent(got, want)
+	if err != nil {
+		t.Fatalf("comparing policies: %s", err)
+	}
+
+	if !equivalent {
+		t.Errorf("got %s, want equivalent of %s", got, want)
+	}
+}
+
+func TestExpandRoleAssumeRolePolicyDocument_conditions(t *testing.T) {
+	t.Parallel()
+
+	// Conditions with the same test are merged into one operator map.
+	d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().Schema, map[string]interface{}{
+		"assume_role_policy_document": []interface{}{
+			map[string]interface{}{
+				"statement": []interface{}{
+					map[string]interface{}{
+						"actions": []interface{}{"sts:AssumeRole"},
+						"principals": []interface{}{
+							map[string]interface{}{
+								"type":        "AWS",
+								"identifiers": []interface{}{"arn:aws:iam::111122223333:root"}, // lintignore:AWSAT005
+							},
+						},
+						"condition": []interface{}{
+							map[string]interface{}{
+								"test":     "StringEquals",
+								"variable": "sts:ExternalId",
+								"values":   []interface{}{"example-external-id"},
+							},
+							map[string]interface{}{
+								"test":     "StringEquals",
+								"variable": "aws:SourceAccount",
+								"values":   []interface{}{"111122223333", "444455556666"},
+							},
+							map[string]interface{}{
+								"test":     "ArnLike",
+								"variable": "aws:SourceArn",
+								"values":   []interface{}{"arn:aws:s3:::example-*"}, // lintignore:AWSAT005
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	got, err := tfiam.ExpandRoleAssumeRolePolicy(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "sts:AssumeRole",
+    "Principal": {"AWS": "arn:aws:iam::111122223333:root"},
+    "Condition": {
+      "StringEquals": {
+        "sts:ExternalId": "example-external-id",
+        "aws:SourceAccount": ["111122223333", "444455556666"]
+      },
+      "ArnLike": {"aws:SourceArn": "arn:aws:s3:::example-*"}
+    }
+  }]
+}` // lintignore:AWSAT005
+
+	equivalent, err := awspolicy.PoliciesAreEquivalent(got, want)
+	if err != nil {
+		t.Fatalf("comparing policies: %s", err)
+	}
+
+	if !equivalent {
+		t.Errorf("got %s, want equivalent of %s", got, want)
+	}
+
+	var doc struct {
+		Statement []struct {
+			Condition map[string]map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(got), &doc); err != nil {
+		t.Fatalf("unmarshaling policy: %s", err)
+	}
+
+	if got, want := len(doc.Statement[0].Condition["StringEquals"]), 2; got != want {
+		t.Errorf("got %d StringEquals keys, want %d", got, want)
+	}
+}
+
+func TestExpandRoleAssumeRolePolicyDocument_sessionTagKeys(t *testing.T) {
+	t.Parallel()
+
+	principals := func(identifiers ...interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"type":        "AWS",
+				"identifiers": identifiers,
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().Schema, map[string]interface{}{
+		"assume_role_policy_document": []interface{}{
+			map[string]interface{}{
+				"session_tag_keys": []interface{}{"Team", "Project"},
+				"statement": []interface{}{
+					map[string]interface{}{
+						"actions":    []interface{}{"sts:AssumeRole"},
+						"principals": principals("arn:aws:iam::123456789012:root"), // lintignore:AWSAT005
+					},
+					map[string]interface{}{
+						"actions":    []interface{}{"sts:AssumeRole"},
+						"principals": principals("arn:aws:iam::123456789012:root", "arn:aws:iam::210987654321:root"), // lintignore:AWSAT005
+					},
+					map[string]interface{}{
+						"actions":    []interface{}{"sts:AssumeRole"},
+						"effect":     "Deny",
+						"principals": principals("arn:aws:iam::999999999999:root"), // lintignore:AWSAT005
+					},
+				},
+			},
+		},
+	})
+
+	got, err := tfiam.ExpandRoleAssumeRolePolicy(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "sts:AssumeRole",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:root"}
+    },
+    {
+      "Effect": "Allow",
+      "Action": "sts:AssumeRole",
+      "Principal": {"AWS": ["arn:aws:iam::123456789012:root", "arn:aws:iam::210987654321:root"]}
+    },
+    {
+      "Effect": "Deny",
+      "Action": "sts:AssumeRole",
+      "Principal": {"AWS": "arn:aws:iam::999999999999:root"}
+    },
+    {
+      "Effect": "Allow",
+      "Action": "sts:TagSession",
+      "Principal": {"AWS": ["arn:aws:iam::123456789012:root", "arn:aws:iam::210987654321:root"]},
+      "Condition": {
+        "ForAllValues:StringEquals": {
+          "aws:TagKeys": ["Project", "Team"],
+          "sts:TransitiveTagKeys": ["Project", "Team"]
+        }
+      }
+    }
+  ]
+}` // lintignore:AWSAT005
+
+	equivalent, err := awspolicy.PoliciesAreEquivalent(got, want)
+	if err != nil {
+		t.Fatalf("comparing policies: %s", err)
+	}
+
+	if !equivalent {
+		t.Errorf("got %s, want equivalent of %s", got, want)
+	}
+}
+
+func TestExpandRoleAssumeRolePolicyDocument_sessionTagKeysNoPrincipals(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().Schema, map[string]interface{}{
+		"assume_role_policy_document": []interface{}{
+			map[string]interface{}{
+				"session_tag_keys": []interface{}{"Team"},
+				"statement": []interface{}{
+					map[string]interface{}{
+						"actions": []interface{}{"sts:AssumeRole"},
+					},
+				},
+			},
+		},
+	})
+
+	_, err := tfiam.ExpandRoleAssumeRolePolicy(d)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if got, want := err.Error(), "session_tag_keys requires an Allow statement with principals"; !strings.Contains(got, want) {
+		t.Errorf("error %q does not contain %q", got, want)
+	}
+}
+
+func TestExpandRoleManagedPolicyNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		partition string
+		path      string
+		names     []string
+		want      []string
+	}{
+		"default path": {
+			partition: "aws",
+			path:      "/",
+			names:     []string{"one", "two"},
+			want: []string{
+				"arn:aws:iam::123456789012:policy/one", // lintignore:AWSAT005
+				"arn:aws:iam::123456789012:policy/two", // lintignore:AWSAT005
+			},
+		},
+		"custom path": {
+			partition: "aws",
+			path:      "/tf-testing/nested/",
+			names:     []string{"one"},
+			want: []string{
+				"arn:aws:iam::123456789012:policy/tf-testing/nested/one", // lintignore:AWSAT005
+			},
+		},
+		"other partition": {
+			partition: "aws-us-gov",
+			path:      "/",
+			names:     []string{"one"},
+			want: []string{
+				"arn:aws-us-gov:iam::123456789012:policy/one", // lintignore:AWSAT005
+			},
+		},
+		"none": {
+			partition: "aws",
+			path:      "/",
+			want:      []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tfiam.ExpandRoleManagedPolicyNames(testCase.partition, "123456789012", testCase.path, testCase.names)
+
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestExpandRoleTrustedServicesPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		services []string
+		want     string
+	}{
+		"single": {
+			services: []string{"ec2.amazonaws.com"},
+			want:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`,
+		},
+		"multiple": {
+			services: []string{"lambda.amazonaws.com", "ec2.amazonaws.com"},
+			want:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":["ec2.amazonaws.com","lambda.amazonaws.com"]}}]}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfiam.ExpandRoleTrustedServicesPolicy(testCase.services)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			equivalent, err := awspolicy.PoliciesAreEquivalent(got, testCase.want)
+			if err != nil {
+				t.Fatalf("comparing policies: %s", err)
+			}
+
+			if !equivalent {
+				t.Errorf("got %s, want equivalent of %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRoleTrimTags(t *testing.T) {
+	t.Parallel()
+
+	configured := map[string]interface{}{
+		" Key1 ": " Value1 ",
+		"Key2":   "Value2",
+	}
+
+	trimmed := tfiam.TrimRoleTags([]*iam.Tag{
+		{Key: aws.String(" Key1 "), Value: aws.String(" Value1 ")},
+		{Key: aws.String("Key2"), Value: aws.String("Value2")},
+	})
+
+	got := make(map[string]string)
+	for _, tag := range trimmed {
+		got[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	if want := map[string]string{"Key1": "Value1", "Key2": "Value2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trimmed tags: got %v, want %v", got, want)
+	}
+
+	// Tags read back from IAM are mapped to their configured form; others are left as read.
+	untrimmed := tfiam.UntrimRoleTags(append(trimmed, &iam.Tag{Key: aws.String("Key3"), Value: aws.String("Value3")}), configured)
+
+	got = make(map[string]string)
+	for _, tag := range untrimmed {
+		got[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	if want := map[string]string{" Key1 ": " Value1 ", "Key2": "Value2", "Key3": "Value3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("untrimmed tags: got %v, want %v", got, want)
+	}
+}
+
+func TestRoleAutoTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		ignoreConfig *tftags.IgnoreConfig
+		tags         map[string]string
+		want         map[string]string
+	}{
+		"no tags": {
+			want: map[string]string{"Name": "test"},
+		},
+		"other tags": {
+			tags: map[string]string{"Key1": "Value1"},
+			want: map[string]string{"Name": "test"},
+		},
+		"configured Name": {
+			tags: map[string]string{"Name": "custom"},
+			want: map[string]string{},
+		},
+		"ignored key": {
+			ignoreConfig: &tftags.IgnoreConfig{Keys: tftags.New(ctx, []interface{}{"Name"})},
+			want:         map[string]string{},
+		},
+		"ignored key prefix": {
+			ignoreConfig: &tftags.IgnoreConfig{KeyPrefixes: tftags.New(ctx, []interface{}{"Na"})},
+			want:         map[string]string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RoleAutoTags(testCase.ignoreConfig, "test", tftags.New(ctx, testCase.tags)).Map(); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRemoveRoleAutoTags(t *testing.T) {
+	t.Parallel()
+
+	tags := []*iam.Tag{
+		{Key: aws.String("Name"), Value: aws.String("test")},
+		{Key: aws.String("Key1"), Value: aws.String("Value1")},
+	}
+
+	if got, want := tfiam.RemoveRoleAutoTags(tags, "test", map[string]interface{}{"Key1": "Value1"}), tags[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A configured Name tag is kept.
+	if got := tfiam.RemoveRoleAutoTags(tags, "test", map[string]interface{}{"Name": "test"}); !reflect.DeepEqual(got, tags) {
+		t.Errorf("got %v, want %v", got, tags)
+	}
+
+	// A Name tag with a different value was not added by auto_tag_name.
+	renamed := []*iam.Tag{{Key: aws.String("Name"), Value: aws.String("other")}}
+	if got := tfiam.RemoveRoleAutoTags(renamed, "test", nil); !reflect.DeepEqual(got, renamed) {
+		t.Errorf("got %v, want %v", got, renamed)
+	}
+}
+
+func TestSplitRoleExternalTags(t *testing.T) {
+	t.Parallel()
+
+	tags := []*iam.Tag{
+		{Key: aws.String("Key1"), Value: aws.String("Value1")},
+		{Key: aws.String("CostCenter"), Value: aws.String("1234")},
+		{Key: aws.String("Key2"), Value: aws.String("Value2")},
+	}
+
+	managed, external := tfiam.SplitRoleExternalTags(tags, map[string]interface{}{"Key1": "Value1", "Key2": "Other"})
+
+	if want := []*iam.Tag{tags[0], tags[2]}; !reflect.DeepEqual(managed, want) {
+		t.Errorf("managed: got %v, want %v", managed, want)
+	}
+
+	if want := tags[1:2]; !reflect.DeepEqual(external, want) {
+		t.Errorf("external: got %v, want %v", external, want)
+	}
+
+	// With no managed tags, every tag is external.
+	if managed, external := tfiam.SplitRoleExternalTags(tags, nil); len(managed) != 0 || !reflect.DeepEqual(external, tags) {
+		t.Errorf("got managed %v, external %v, want all external", managed, external)
+	}
+}
+
+func TestRoleIgnoreTagsConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+	providerConfig := &tftags.IgnoreConfig{
+		Keys: tftags.New(ctx, []string{"ignored"}),
+	}
+	tags := tftags.New(ctx, map[string]string{
+		"ignored":       "value1",
+		"tooling:owner": "value2",
+		"Name":          "value3",
+	})
+
+	testCases := map[string]struct {
+		raw  map[string]interface{}
+		want []string
+	}{
+		"provider config": {
+			raw:  map[string]interface{}{},
+			want: []string{"Name", "tooling:owner"},
+		},
+		"override keys": {
+			raw:  map[string]interface{}{"ignore_tag_keys": []interface{}{"Name"}},
+			want: []string{"ignored", "tooling:owner"},
+		},
+		"override prefixes": {
+			raw:  map[string]interface{}{"ignore_tag_prefixes": []interface{}{"tooling:"}},
+			want: []string{"Name", "ignored"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().SchemaMap(), testCase.raw)
+
+			got := tags.IgnoreConfig(tfiam.RoleIgnoreTagsConfig(ctx, d, providerConfig)).Keys()
+			slices.Sort(got)
+
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRoleExternalInlinePolicyNames(t *testing.T) {
+	t.Parallel()
+
+	inlinePolicies := schema.NewSet(schema.HashResource(tfiam.ResourceRole().SchemaMap()["inline_policy"].Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"name": "managed", "policy": "{}"},
+	})
+	inlinePoliciesMap := map[string]interface{}{"managed-map": "{}"}
+
+	testCases := map[string]struct {
+		policyNames []string
+		want        []string
+	}{
+		"none": {},
+		"managed only": {
+			policyNames: []string{"managed", "managed-map"},
+		},
+		"external": {
+			policyNames: []string{"external-b", "managed", "managed-map", "external-a"},
+			want:        []string{"external-a", "external-b"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RoleExternalInlinePolicyNames(testCase.policyNames, inlinePolicies, inlinePoliciesMap); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestExpandRoleInlinePoliciesMapChanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		o, n            map[string]interface{}
+		wantPolicyNames []string
+		wantPuts        []string
+	}{
+		"create": {
+			n:        map[string]interface{}{"b": "{}", "a": "{}"},
+			wantPuts: []string{"a", "b"},
+		},
+		"add": {
+			o:        map[string]interface{}{"a": "{}"},
+			n:        map[string]interface{}{"a": "{}", "b": "{}"},
+			wantPuts: []string{"b"},
+		},
+		"change": {
+			o:        map[string]interface{}{"a": "{}", "b": "{}"},
+			n:        map[string]interface{}{"a": "{}", "b": `{"Version":"2012-10-17"}`},
+			wantPuts: []string{"b"},
+		},
+		"remove": {
+			o:               map[string]interface{}{"a": "{}", "b": "{}", "c": "{}"},
+			n:               map[string]interface{}{"b": "{}"},
+			wantPolicyNames: []string{"a", "c"},
+		},
+		"remove all": {
+			o:               map[string]interface{}{"a": "{}"},
+			n:               map[string]interface{}{},
+			wantPolicyNames: []string{"a"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policyNames, policies := tfiam.ExpandRoleInlinePoliciesMapChanges("test-role", testCase.o, testCase.n)
+
+			if got := aws.StringValueSlice(policyNames); !reflect.DeepEqual(got, testCase.wantPolicyNames) && (len(got) > 0 || len(testCase.wantPolicyNames) > 0) {
+				t.Errorf("deleted policy names: got %v, want %v", got, testCase.wantPolicyNames)
+			}
+
+			var puts []string
+			for _, policy := range policies {
+				if got, want := aws.StringValue(policy.RoleName), "test-role"; got != want {
+					t.Errorf("RoleName: got %q, want %q", got, want)
+				}
+				if got, want := aws.StringValue(policy.PolicyDocument), testCase.n[aws.StringValue(policy.PolicyName)]; got != want {
+					t.Errorf("PolicyDocument (%s): got %q, want %q", aws.StringValue(policy.PolicyName), got, want)
+				}
+				puts = append(puts, aws.StringValue(policy.PolicyName))
+			}
+
+			if !reflect.DeepEqual(puts, testCase.wantPuts) {
+				t.Errorf("put policy names: got %v, want %v", puts, testCase.wantPuts)
+			}
+		})
+	}
+}
+
+func TestFlattenRoleInlinePoliciesMap(t *testing.T) {
+	t.Parallel()
+
+	const (
+		compact = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+		// prior is equivalent to compact, with Action as a list.
+		prior = `{"Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`
+	)
+
+	apiObjects := []*iam.PutRolePolicyInput{
+		{PolicyName: aws.String("prior"), PolicyDocument: aws.String(compact)},
+		{PolicyName: aws.String("external"), PolicyDocument: aws.String(compact)},
+	}
+
+	got, err := tfiam.FlattenRoleInlinePoliciesMap(apiObjects, map[string]interface{}{"prior": prior})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"prior":    prior,
+		"external": compact,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeRoleInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	policies := []*iam.PutRolePolicyInput{
+		{
+			PolicyName: aws.String("read"),
+			PolicyDocument: aws.String(`{
+  "Statement": [
+    {
+      "Resource": "*",
+      "Effect": "Allow",
+      "Action": "ec2:Describe*"
+    }
+  ],
+  "Version": "2012-10-17"
+}`),
+		},
+		{
+			PolicyName: aws.String("empty"),
+		},
+	}
+
+	if err := tfiam.NormalizeRoleInlinePolicies(policies); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := aws.StringValue(policies[0].PolicyDocument), `{"Version":"2012-10-17","Statement":[{"Action":"ec2:Describe*","Effect":"Allow","Resource":"*"}]}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if policies[1].PolicyDocument != nil {
+		t.Errorf("got %s, want no document", aws.StringValue(policies[1].PolicyDocument))
+	}
+
+	invalid := []*iam.PutRolePolicyInput{{PolicyName: aws.String("invalid"), PolicyDocument: aws.String(`{`)}}
+	if err := tfiam.NormalizeRoleInlinePolicies(invalid); err == nil || !strings.Contains(err.Error(), "inline policy (invalid)") {
+		t.Errorf("got error %v, want error for inline policy (invalid)", err)
+	}
+}
+
+func TestAppendRoleBoundaryCappedPolicyWarnings(t *testing.T) {
+	t.Parallel()
+
+	const boundary = "arn:aws:iam::123456789012:policy/boundary" // lintignore:AWSAT005
+
+	testCases := map[string]struct {
+		permissionsBoundary string
+		policyARNs          []string
+		wantWarnings        int
+	}{
+		"administrator access with boundary": {
+			permissionsBoundary: boundary,
+			policyARNs:          []string{"arn:aws:iam::aws:policy/AdministratorAccess"}, // lintignore:AWSAT005
+			wantWarnings:        1,
+		},
+		"administrator access without boundary": {
+			policyARNs: []string{"arn:aws:iam::aws:policy/AdministratorAccess"}, // lintignore:AWSAT005
+		},
+		"other partition": {
+			permissionsBoundary: boundary,
+			policyARNs: []string{
+				"arn:aws-us-gov:iam::aws:policy/AdministratorAccess", // lintignore:AWSAT005
+				"arn:aws-us-gov:iam::aws:policy/PowerUserAccess",     // lintignore:AWSAT005
+			},
+			wantWarnings: 2,
+		},
+		"narrow AWS managed policy": {
+			permissionsBoundary: boundary,
+			policyARNs:          []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"}, // lintignore:AWSAT005
+		},
+		"customer managed policy with the same name": {
+			permissionsBoundary: boundary,
+			policyARNs:          []string{"arn:aws:iam::123456789012:policy/AdministratorAccess"}, // lintignore:AWSAT005
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := tfiam.AppendRoleBoundaryCappedPolicyWarnings(nil, "test", testCase.permissionsBoundary, testCase.policyARNs)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got, want := len(diags), testCase.wantWarnings; got != want {
+				t.Errorf("got %d warnings, want %d: %v", got, want, diags)
+			}
+
+			for _, d := range diags {
+				if d.Severity != diag.Warning || !strings.Contains(d.Summary, "capped by the boundary") {
+					t.Errorf("unexpected diagnostic: %v", d)
+				}
+			}
+		})
+	}
+}
+
+func TestRoleImportedManagedPolicyDetachments(t *testing.T) {
+	t.Parallel()
+
+	const (
+		policy1 = "arn:aws:iam::123456789012:policy/policy1" // lintignore:AWSAT005
+		policy2 = "arn:aws:iam::123456789012:policy/policy2" // lintignore:AWSAT005
+	)
+
+	testCases := map[string]struct {
+		importPending bool
+		policyARNs    []string
+		wantDetach    []string
+		wantWarning   bool
+	}{
+		"not imported": {
+			policyARNs: []string{policy1},
+			wantDetach: []string{policy1},
+		},
+		"imported": {
+			importPending: true,
+			policyARNs:    []string{policy2, policy1},
+			wantWarning:   true,
+		},
+		"imported nothing to detach": {
+			importPending: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policyARNs, diags := tfiam.RoleImportedManagedPolicyDetachments(nil, "test", testCase.importPending, aws.StringSlice(testCase.policyARNs))
+
+			if got := aws.StringValueSlice(policyARNs); len(got) > 0 || len(testCase.wantDetach) > 0 {
+				if !reflect.DeepEqual(got, testCase.wantDetach) {
+					t.Errorf("got %v, want %v", got, testCase.wantDetach)
+				}
+			}
+
+			if !testCase.wantWarning {
+				if len(diags) != 0 {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if got, want := len(diags), 1; got != want {
+				t.Fatalf("got %d diagnostics, want %d: %v", got, want, diags)
+			}
+
+			if got, want := diags[0].Severity, diag.Warning; got != want {
+				t.Errorf("got severity %v, want %v", got, want)
+			}
+
+			if got, want := diags[0].Summary, policy1+", "+policy2; !strings.Contains(got, want) {
+				t.Errorf("got summary %q, want it to contain %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAppendRoleExternalManagedPolicyWarnings(t *testing.T) {
+	t.Parallel()
+
+	const (
+		policy1 = "arn:aws:iam::123456789012:policy/policy1" // lintignore:AWSAT005
+		policy2 = "arn:aws:iam::123456789012:policy/policy2" // lintignore:AWSAT005
+		policy3 = "arn:aws:iam::123456789012:policy/policy3" // lintignore:AWSAT005
+	)
+
+	testCases := map[string]struct {
+		attrs        map[string]cty.Value
+		policyARNs   []string
+		wantExternal string
+	}{
+		"unchanged": {
+			attrs:      map[string]cty.Value{"managed_policy_arns": cty.SetVal([]cty.Value{cty.StringVal(policy1)})},
+			policyARNs: []string{policy1},
+		},
+		"detached out of band": {
+			attrs:      map[string]cty.Value{"managed_policy_arns": cty.SetVal([]cty.Value{cty.StringVal(policy1), cty.StringVal(policy2)})},
+			policyARNs: []string{policy1},
+		},
+		"attached out of band": {
+			attrs:        map[string]cty.Value{"managed_policy_arns": cty.SetVal([]cty.Value{cty.StringVal(policy1)})},
+			policyARNs:   []string{policy3, policy1, policy2},
+			wantExternal: policy2 + ", " + policy3,
+		},
+		"none configured": {
+			attrs:        map[string]cty.Value{"managed_policy_arns": cty.SetValEmpty(cty.String)},
+			policyARNs:   []string{policy1},
+			wantExternal: policy1,
+		},
+		"not configured": {
+			attrs:      map[string]cty.Value{"name": cty.StringVal("test")},
+			policyARNs: []string{policy1},
+		},
+		"unknown": {
+			attrs:      map[string]cty.Value{"managed_policy_arns": cty.UnknownVal(cty.Set(cty.String))},
+			policyARNs: []string{policy1},
+		},
+		"no configuration": {
+			policyARNs: []string{policy1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := testRoleResourceDataWithRawConfig(testCase.attrs, false)
+			diags := tfiam.AppendRoleExternalManagedPolicyWarnings(nil, d, testCase.policyARNs)
+
+			if testCase.wantExternal == "" {
+				if len(diags) != 0 {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+
+				return
+			}
+
+			if got, want := len(diags), 1; got != want {
+				t.Fatalf("got %d diagnostics, want %d: %v", got, want, diags)
+			}
+
+			if d := diags[0]; d.Severity != diag.Warning || !strings.Contains(d.Summary, "attached outside of this resource: "+testCase.wantExternal+".") {
+				t.Errorf("unexpected diagnostic: %v", d)
+			}
+		})
+	}
+}
+
+func TestAttachRoleManagedPolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	var policyARNs []string
+	for i := 0; i < 12; i++ {
+		policyARNs = append(policyARNs, fmt.Sprintf("arn:aws:iam::123456789012:policy/policy-%02d", i)) // lintignore:AWSAT005
+	}
+
+	// The role is allowed 10 managed policies.
+	var mu sync.Mutex
+	var attached []string
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"AttachRolePolicy": func(r *request.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(attached) >= 10 {
+				r.Error = awserr.New(iam.ErrCodeLimitExceededException, "Cannot exceed quota for PoliciesPerRole: 10", nil)
+				return
+			}
+
+			attached = append(attached, aws.StringValue(r.Params.(*iam.AttachRolePolicyInput).PolicyArn))
+		},
+	})
+
+	err := tfiam.AttachRoleManagedPolicies(ctx, conn, "test", policyARNs, 2*time.Minute)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if got, want := len(attached), 10; got != want {
+		t.Errorf("got %d policies attached, want %d", got, want)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"attaching 12 managed policies to IAM Role (test)", "2 not attached", "Service Quotas"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q does not contain %q", msg, want)
+		}
+	}
+
+	for _, policyARN := range policyARNs {
+		if got, want := strings.Contains(msg, policyARN), !slices.Contains(attached, policyARN); got != want {
+			t.Errorf("error reports %s as not attached: %t, want %t", policyARN, got, want)
+		}
+	}
+}
+
+func TestPutRoleInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// The role is not yet visible to the first PutRolePolicy calls for each policy.
+	calls := make(map[string]int)
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"PutRolePolicy": func(r *request.Request) {
+			policyName := aws.StringValue(r.Params.(*iam.PutRolePolicyInput).PolicyName)
+			calls[policyName]++
+
+			if calls[policyName] <= 2 {
+				r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "The role with name test cannot be found.", nil)
+			}
+		},
+	})
+
+	policies := []*iam.PutRolePolicyInput{
+		{PolicyDocument: aws.String(`{}`), PolicyName: aws.String("one"), RoleName: aws.String("test")},
+		{PolicyDocument: aws.String(`{}`), PolicyName: aws.String("two"), RoleName: aws.String("test")},
+	}
+
+	if err := tfiam.PutRoleInlinePolicies(ctx, conn, policies, false, 2*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, policyName := range []string{"one", "two"} {
+		if got, want := calls[policyName], 3; got != want {
+			t.Errorf("got %d PutRolePolicy calls for %s, want %d", got, policyName, want)
+		}
+	}
+}
+
+func TestPutRoleInlinePolicies_errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"PutRolePolicy": func(r *request.Request) {
+			r.Error = awserr.New(iam.ErrCodeMalformedPolicyDocumentException, "Syntax errors in policy.", nil)
+		},
+	})
+
+	policies := []*iam.PutRolePolicyInput{
+		{PolicyDocument: aws.String(`{`), PolicyName: aws.String("one"), RoleName: aws.String("test")},
+		{PolicyDocument: aws.String(`{`), PolicyName: aws.String("two"), RoleName: aws.String("test")},
+	}
+
+	err := tfiam.PutRoleInlinePolicies(ctx, conn, policies, false, 2*time.Minute)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	for _, want := range []string{"adding inline policy (one)", "adding inline policy (two)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestPutRoleInlinePolicies_rollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		rollback bool
+		want     []string
+	}{
+		"rollback": {
+			rollback: true,
+			want:     []string{},
+		},
+		"no rollback": {
+			rollback: false,
+			want:     []string{"one", "three"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// The role's inline policies; adding "two" fails.
+			policyNames := make(map[string]bool)
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"PutRolePolicy": func(r *request.Request) {
+					policyName := aws.StringValue(r.Params.(*iam.PutRolePolicyInput).PolicyName)
+					if policyName == "two" {
+						r.Error = awserr.New(iam.ErrCodeMalformedPolicyDocumentException, "Syntax errors in policy.", nil)
+						return
+					}
+					policyNames[policyName] = true
+				},
+				"DeleteRolePolicy": func(r *request.Request) {
+					delete(policyNames, aws.StringValue(r.Params.(*iam.DeleteRolePolicyInput).PolicyName))
+				},
+			})
+
+			policies := []*iam.PutRolePolicyInput{
+				{PolicyDocument: aws.String(`{}`), PolicyName: aws.String("one"), RoleName: aws.String("test")},
+				{PolicyDocument: aws.String(`{`), PolicyName: aws.String("two"), RoleName: aws.String("test")},
+				{PolicyDocument: aws.String(`{}`), PolicyName: aws.String("three"), RoleName: aws.String("test")},
+			}
+
+			err := tfiam.PutRoleInlinePolicies(ctx, conn, policies, testCase.rollback, 2*time.Minute)
+
+			if err == nil || !strings.Contains(err.Error(), "adding inline policy (two)") {
+				t.Fatalf("expected error adding inline policy (two), got %v", err)
+			}
+
+			got := []string{}
+			for policyName := range policyNames {
+				got = append(got, policyName)
+			}
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got inline policies %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRole_errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// Listing the role's managed policies fails.
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"ListInstanceProfilesForRole": func(*request.Request) {},
+		"ListAttachedRolePolicies": func(r *request.Request) {
+			r.Error = awserr.NewRequestFailure(awserr.New("AccessDenied", "User is not authorized to perform: iam:ListAttachedRolePolicies", nil), http.StatusForbidden, "example-request-id")
+		},
+	})
+
+	err := tfiam.DeleteRole(ctx, conn, "test", true, false, false, nil, 2*time.Minute)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	for _, want := range []string{"reading IAM Role (test) managed policies", "AccessDenied", "example-request-id"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	var requestFailure awserr.RequestFailure
+	if !errors.As(err, &requestFailure) {
+		t.Fatalf("error %q does not wrap the AWS request failure", err)
+	}
+
+	if got, want := requestFailure.RequestID(), "example-request-id"; got != want {
+		t.Errorf("got request ID %q, want %q", got, want)
+	}
+}
+
+func TestRoleError(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		err  *tfiam.RoleError
+		want string
+	}{
+		"create": {
+			err:  &tfiam.RoleError{Operation: tfiam.RoleOperationCreate, RoleName: "test", Err: errTest},
+			want: "creating IAM Role (test): test error",
+		},
+		"read": {
+			err:  &tfiam.RoleError{Operation: tfiam.RoleOperationRead, RoleName: "test", Err: errTest},
+			want: "reading IAM Role (test): test error",
+		},
+		"update": {
+			err:  &tfiam.RoleError{Operation: tfiam.RoleOperationUpdate, RoleName: "test", Err: errTest},
+			want: "updating IAM Role (test): test error",
+		},
+		"delete": {
+			err:  &tfiam.RoleError{Operation: tfiam.RoleOperationDelete, RoleName: "test", Err: errTest},
+			want: "deleting IAM Role (test): test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.err.Error(); got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+
+			if err := fmt.Errorf("wrapped: %w", testCase.err); !errors.Is(err, errTest) {
+				t.Errorf("error %q does not wrap %q", err, errTest)
+			}
+		})
+	}
+}
+
+func TestRoleAssumeRolePolicyProblems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy string
+		want   []string
+	}{
+		"valid": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`,
+		},
+		"valid single statement object": {
+			policy: `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":["sts:AssumeRole","sts:TagSession"],"Principal":"*"}}`,
+		},
+		"invalid JSON": {
+			policy: `{"Version":"2012-10-17",`,
+			want:   []string{"not valid JSON: unexpected end of JSON input"},
+		},
+		"no statements": {
+			policy: `{"Version":"2012-10-17","Statement":[]}`,
+			want:   []string{"Statement is missing or empty"},
+		},
+		"invalid version": {
+			policy: `{"Version":"2012-10-18","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":"*"}]}`,
+			want:   []string{"Version 2012-10-18 is not 2012-10-17 or 2008-10-17"},
+		},
+		"empty action": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Sid":"Trust","Effect":"Allow","Action":[],"Principal":{"AWS":"arn:aws:iam::123456789012:root"}}]}`, // lintignore:AWSAT005
+			want:   []string{`Statement[0] (Sid "Trust"): Action is empty`},
+		},
+		"invalid effect": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":"*"},{"Effect":"allow","Action":"sts:AssumeRole","Principal":"*"}]}`,
+			want:   []string{`Statement[1]: Effect "allow" is not Allow or Deny`},
+		},
+		"missing fields": {
+			policy: `{"Version":"2012-10-17","Statement":[{}]}`,
+			want: []string{
+				"Statement[0]: Effect is missing",
+				"Statement[0]: Action is missing",
+				"Statement[0]: Principal is missing, a trust policy must specify who can assume the role",
+			},
+		},
+		"malformed values": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["AssumeRole",""],"Principal":{"Service":[],"AWS":"*"},"Resource":"*"}]}`,
+			want: []string{
+				`Statement[0]: Action "AssumeRole" is not of the form service:action`,
+				"Statement[0]: Action contains an empty value",
+				"Statement[0]: Principal Service has no identifiers",
+				"Statement[0]: Resource is not allowed in a trust policy",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RoleAssumeRolePolicyProblems(testCase.policy); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRoleAssumeRolePolicyError(t *testing.T) {
+	t.Parallel()
+
+	malformed := awserr.New(iam.ErrCodeMalformedPolicyDocumentException, "Syntax errors in policy.", nil)
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Permit","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`
+
+	err := tfiam.RoleAssumeRolePolicyError(policy, malformed)
+
+	if got, want := err.Error(), `MalformedPolicyDocument: Syntax errors in policy.; problems found in the policy document: Statement[0]: Effect "Permit" is not Allow or Deny`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var malformedErr *tfiam.MalformedPolicyDocumentError
+	if !errors.As(err, &malformedErr) {
+		t.Fatalf("error %q is not a MalformedPolicyDocumentError", err)
+	}
+
+	if !tfawserr.ErrCodeEquals(err, iam.ErrCodeMalformedPolicyDocumentException) {
+		t.Errorf("error %q does not wrap the AWS error", err)
+	}
+
+	// No problem found locally.
+	valid := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"Service":"ec2.amazonaws.com"}}]}`
+	if err := tfiam.RoleAssumeRolePolicyError(valid, malformed); err != malformed {
+		t.Errorf("got %v, want %v", err, malformed)
+	}
+
+	// Other errors are unchanged.
+	accessDenied := awserr.New("AccessDenied", "User is not authorized to perform: iam:CreateRole", nil)
+	if err := tfiam.RoleAssumeRolePolicyError(policy, accessDenied); err != accessDenied {
+		t.Errorf("got %v, want %v", err, accessDenied)
+	}
+}
+
+func TestDeleteRole_roleError(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"ListInstanceProfilesForRole": func(r *request.Request) {
+			r.Error = awserr.New("AccessDenied", "User is not authorized to perform: iam:ListInstanceProfilesForRole", nil)
+		},
+	})
+
+	err := tfiam.DeleteRole(ctx, conn, "test", false, false, false, nil, 2*time.Minute)
+
+	var roleErr *tfiam.RoleError
+	if !errors.As(err, &roleErr) {
+		t.Fatalf("error %q is not a RoleError", err)
+	}
+
+	if got, want := roleErr.Operation, tfiam.RoleOperationDelete; got != want {
+		t.Errorf("got operation %q, want %q", got, want)
+	}
+
+	if got, want := roleErr.RoleName, "test"; got != want {
+		t.Errorf("got role name %q, want %q", got, want)
+	}
+
+	if !tfawserr.ErrCodeEquals(err, "AccessDenied") {
+		t.Errorf("error %q does not wrap the AWS error", err)
+	}
+}
+
+func TestDeleteRole_timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// The role never stops reporting attachments.
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"ListInstanceProfilesForRole": func(*request.Request) {},
+		"DeleteRole": func(r *request.Request) {
+			r.Error = awserr.New(iam.ErrCodeDeleteConflictException, "Cannot delete entity, must detach all policies first.", nil)
+		},
+	})
+
+	start := time.Now()
+	err := tfiam.DeleteRole(ctx, conn, "test", false, false, false, nil, 1*time.Second)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !tfawserr.ErrCodeEquals(err, iam.ErrCodeDeleteConflictException) {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("DeleteRole took %s, want it bounded by the 1s timeout", elapsed)
+	}
+}
+
+func TestDeleteRole_instanceProfileTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// The instance profile never stops reporting a conflict.
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"ListInstanceProfilesForRole": func(r *request.Request) {
+			r.Data.(*iam.ListInstanceProfilesForRoleOutput).InstanceProfiles = []*iam.InstanceProfile{
+				{InstanceProfileName: aws.String("test")},
+			}
+		},
+		"RemoveRoleFromInstanceProfile": func(r *request.Request) {
+			r.Error = awserr.New(iam.ErrCodeDeleteConflictException, "Cannot remove role from instance profile while it is being modified.", nil)
+		},
+	})
+
+	start := time.Now()
+	err := tfiam.DeleteRole(ctx, conn, "test", false, false, false, nil, 1*time.Second)
+
+	if !tfawserr.ErrCodeEquals(err, iam.ErrCodeDeleteConflictException) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("DeleteRole took %s, want it bounded by the 1s timeout", elapsed)
+	}
+}
+
+func TestRolePropagationTimeout(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		meta interface{}
+		want time.Duration
+	}{
+		{
+			name: "unset",
+			meta: &conns.AWSClient{},
+			want: 2 * time.Minute,
+		},
+		{
+			name: "set",
+			meta: &conns.AWSClient{IAMPropagationTimeout: 5 * time.Minute},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "no client",
+			meta: nil,
+			want: 2 * time.Minute,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RolePropagationTimeout(testCase.meta); got != testCase.want {
+				t.Errorf("got %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRolePolicyAttachments(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	var policyARNs []string
+	for i := 0; i < 10; i++ {
+		policyARNs = append(policyARNs, fmt.Sprintf("arn:aws:iam::123456789012:policy/policy-%02d", i)) // lintignore:AWSAT005
+	}
+
+	// policy-03 is already detached and detaching policy-07 is denied.
+	var mu sync.Mutex
+	var detached []string
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"DetachRolePolicy": func(r *request.Request) {
+			policyARN := aws.StringValue(r.Params.(*iam.DetachRolePolicyInput).PolicyArn)
+
+			switch {
+			case strings.HasSuffix(policyARN, "policy-03"):
+				r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "Policy policy-03 was not found.", nil)
+				return
+			case strings.HasSuffix(policyARN, "policy-07"):
+				r.Error = awserr.New("AccessDenied", "User is not authorized to perform: iam:DetachRolePolicy", nil)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			detached = append(detached, policyARN)
+		},
+	})
+
+	err := tfiam.DeleteRolePolicyAttachments(ctx, conn, "test", aws.StringSlice(policyARNs), 2*time.Minute)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if got, want := err.Error(), "detaching managed policy (arn:aws:iam::123456789012:policy/policy-07)"; !strings.Contains(got, want) { // lintignore:AWSAT005
+		t.Errorf("error %q does not contain %q", got, want)
+	}
+
+	if strings.Contains(err.Error(), "policy-03") {
+		t.Errorf("error %q reports the already detached policy", err)
+	}
+
+	if got, want := len(detached), 8; got != want {
+		t.Errorf("got %d policies detached, want %d", got, want)
+	}
+}
+
+func TestForEachRoleManagedPolicy(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	attached := make(map[string]bool)
+	active, maxActive := 0, 0
+
+	var policyARNs []string
+	for i := 0; i < 20; i++ {
+		policyARNs = append(policyARNs, fmt.Sprintf("arn:aws:iam::123456789012:policy/policy-%d", i)) // lintignore:AWSAT005
+	}
+	failARN := policyARNs[7]
+
+	err := tfiam.ForEachRoleManagedPolicy(policyARNs, func(arn string) error {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		active--
+
+		if arn == failARN {
+			return fmt.Errorf("attaching managed policy (%s): failed", arn)
+		}
+
+		attached[arn] = true
+
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), failARN) {
+		t.Errorf("expected error to name %q, got: %s", failARN, err)
+	}
+
+	if got, want := len(attached), len(policyARNs)-1; got != want {
+		t.Errorf("got %d policies attached, want %d", got, want)
+	}
+
+	if maxActive > 5 {
+		t.Errorf("got %d concurrent attachments, want at most 5", maxActive)
+	}
+
+	if err := tfiam.ForEachRoleManagedPolicy(nil, func(string) error { return errors.New("unexpected call") }); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestRetryCreateRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// The first calls race with the deletion of a role with the same name.
+	var calls int
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"CreateRole": func(r *request.Request) {
+			calls++
+
+			if calls <= 2 {
+				r.Error = awserr.New(iam.ErrCodeEntityAlreadyExistsException, "Role with name test already exists.", nil)
+				return
+			}
+
+			r.Data.(*iam.CreateRoleOutput).Role = &iam.Role{RoleName: aws.String("test")}
+		},
+	})
+
+	output, err := tfiam.RetryCreateRole(ctx, conn, &iam.CreateRoleInput{RoleName: aws.String("test")}, 2*time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := aws.StringValue(output.Role.RoleName), "test"; got != want {
+		t.Errorf("got role %s, want %s", got, want)
+	}
+
+	if got, want := calls, 3; got != want {
+		t.Errorf("got %d CreateRole calls, want %d", got, want)
+	}
+}
+
+func TestCheckRoleExistingInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	policies := []*iam.PutRolePolicyInput{
+		{RoleName: aws.String("test"), PolicyName: aws.String("policy-b"), PolicyDocument: aws.String(`{}`)},
+		{RoleName: aws.String("test"), PolicyName: aws.String("policy-a"), PolicyDocument: aws.String(`{}`)},
+		{RoleName: aws.String("test"), PolicyName: aws.String("policy-c"), PolicyDocument: aws.String(`{}`)},
+	}
+
+	testCases := map[string]struct {
+		existing       []string
+		failOnExisting bool
+		wantErr        string
+	}{
+		"new role": {
+			failOnExisting: true,
+		},
+		"overwrite": {
+			existing: []string{"policy-a", "policy-b"},
+		},
+		"fail on existing": {
+			existing:       []string{"policy-b", "policy-a", "policy-z"},
+			failOnExisting: true,
+			wantErr:        "role already has inline policies policy-a, policy-b, and fail_on_existing_inline is set",
+		},
+		"fail on existing, none configured": {
+			existing:       []string{"policy-z"},
+			failOnExisting: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfiam.CheckRoleExistingInlinePolicies("test", testCase.existing, policies, testCase.failOnExisting)
+
+			if testCase.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if got := err.Error(); got != testCase.wantErr {
+				t.Errorf("got error %q, want %q", got, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdoptRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	const policy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+
+	testCases := map[string]struct {
+		path    string
+		policy  string
+		wantErr bool
+	}{
+		"equivalent": {
+			path:   "/",
+			policy: `{"Version":"2012-10-17","Statement":[{"Action":["sts:AssumeRole"],"Effect":"Allow","Principal":{"Service":["ec2.amazonaws.com"]}}]}`,
+		},
+		"different policy": {
+			path:    "/",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sts:AssumeRole"}]}`,
+			wantErr: true,
+		},
+		"different path": {
+			path:    "/other/",
+			policy:  policy,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetRole": func(r *request.Request) {
+					r.Data.(*iam.GetRoleOutput).Role = &iam.Role{
+						AssumeRolePolicyDocument: aws.String(url.QueryEscape(testCase.policy)),
+						Path:                     aws.String(testCase.path),
+						RoleName:                 aws.String("test"),
+					}
+				},
+			})
+
+			role, err := tfiam.AdoptRole(ctx, conn, &iam.CreateRoleInput{
+				AssumeRolePolicyDocument: aws.String(policy),
+				Path:                     aws.String("/"),
+				RoleName:                 aws.String("test"),
+			})
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := aws.StringValue(role.RoleName), "test"; got != want {
+				t.Errorf("got role %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		forceDetach bool
+		want        []string
+	}{
+		"default": {
+			want: []string{"ListInstanceProfilesForRole", "DeleteRole"},
+		},
+		"force detach": {
+			forceDetach: true,
+			want:        []string{"ListInstanceProfilesForRole", "ListAttachedRolePolicies", "ListRolePolicies", "DeleteRole"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var operations []string
+			handlers := make(map[string]func(*request.Request))
+			for _, operation := range []string{"ListInstanceProfilesForRole", "ListAttachedRolePolicies", "ListRolePolicies", "DeleteRole"} {
+				handlers[operation] = func(r *request.Request) {
+					operations = append(operations, r.Operation.Name)
+				}
+			}
+			conn := newMockIAMConn(t, handlers)
+
+			if err := tfiam.DeleteRole(ctx, conn, "test", testCase.forceDetach, false, false, nil, 2*time.Minute); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(operations, testCase.want) {
+				t.Errorf("got API calls %v, want %v", operations, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRoleInlinePolicies_noSuchEntity(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	var deleted []string
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"DeleteRolePolicy": func(r *request.Request) {
+			name := aws.StringValue(r.Params.(*iam.DeleteRolePolicyInput).PolicyName)
+			// The first policy has already been deleted.
+			if name == "first" {
+				r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "The role policy with name first cannot be found.", nil)
+				return
+			}
+			deleted = append(deleted, name)
+		},
+	})
+
+	if err := tfiam.DeleteRoleInlinePolicies(ctx, conn, "test", aws.StringSlice([]string{"first", "second"}), 2*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"second"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deleted %v, want %v", deleted, want)
+	}
+}
+
+func TestDeleteRole_inlinePolicyNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		inlinePolicyNames []string
+		external          bool
+		wantDeleted       []string
+		expectedError     *regexp.Regexp
+	}{
+		"all": {
+			wantDeleted: []string{"external", "managed"},
+		},
+		"managed only": {
+			inlinePolicyNames: []string{"managed"},
+			wantDeleted:       []string{"managed"},
+		},
+		"managed only with external remaining": {
+			inlinePolicyNames: []string{"managed"},
+			external:          true,
+			wantDeleted:       []string{"managed"},
+			expectedError:     regexp.MustCompile(`role still has inline policies that are not managed by this resource: external\. IAM cannot delete a role with inline policies`),
+		},
+		"none managed": {
+			inlinePolicyNames: []string{},
+			external:          true,
+			expectedError:     regexp.MustCompile(`not managed by this resource: external, managed\.`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policyNames := []string{"external", "managed"}
+			var deleted []string
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetRole":                     func(*request.Request) {},
+				"ListInstanceProfilesForRole": func(*request.Request) {},
+				"ListAttachedRolePolicies":    func(*request.Request) {},
+				"ListRolePolicies": func(r *request.Request) {
+					r.Data.(*iam.ListRolePoliciesOutput).PolicyNames = aws.StringSlice(policyNames)
+				},
+				"DeleteRolePolicy": func(r *request.Request) {
+					name := aws.StringValue(r.Params.(*iam.DeleteRolePolicyInput).PolicyName)
+					deleted = append(deleted, name)
+					if i := slices.Index(policyNames, name); i >= 0 {
+						policyNames = slices.Delete(policyNames, i, i+1)
+					}
+				},
+				"DeleteRole": func(r *request.Request) {
+					if testCase.external && len(policyNames) > 0 {
+						r.Error = awserr.New(iam.ErrCodeDeleteConflictException, "Cannot delete entity, must delete policies first.", nil)
+					}
+				},
+			})
+
+			err := tfiam.DeleteRole(ctx, conn, "test", true, false, false, testCase.inlinePolicyNames, 2*time.Minute)
+
+			if testCase.expectedError != nil {
+				if err == nil || !testCase.expectedError.MatchString(err.Error()) {
+					t.Fatalf("got error %v, want match for %s", err, testCase.expectedError)
+				}
+
+				if !tfawserr.ErrCodeEquals(err, iam.ErrCodeDeleteConflictException) {
+					t.Errorf("error %q does not wrap the DeleteConflict error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(deleted, testCase.wantDeleted) {
+				t.Errorf("got deleted inline policies %v, want %v", deleted, testCase.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestRoleManagedInlinePolicyNames(t *testing.T) {
+	t.Parallel()
+
+	inlinePolicies := schema.NewSet(schema.HashResource(tfiam.ResourceRole().SchemaMap()["inline_policy"].Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"name": "b", "policy": "{}"},
+		map[string]interface{}{"name": "", "policy": ""},
+	})
+
+	if got, want := tfiam.RoleManagedInlinePolicyNames(inlinePolicies, map[string]interface{}{"a": "{}"}), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := tfiam.RoleManagedInlinePolicyNames(new(schema.Set), nil); got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want an empty, non-nil slice", got)
+	}
+}
+
+func TestUpdateRoleManagedPolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		add           []string
+		remove        []string
+		attachError   bool
+		wantCalls     []string
+		expectedError *regexp.Regexp
+	}{
+		"add and remove": {
+			add:       []string{"arn:aws:iam::aws:policy/New1", "arn:aws:iam::aws:policy/New2"}, // lintignore:AWSAT005
+			remove:    []string{"arn:aws:iam::aws:policy/Old1", "arn:aws:iam::aws:policy/Old2"}, // lintignore:AWSAT005
+			wantCalls: []string{"AttachRolePolicy", "AttachRolePolicy", "DetachRolePolicy", "DetachRolePolicy"},
+		},
+		"add only": {
+			add:       []string{"arn:aws:iam::aws:policy/New1"}, // lintignore:AWSAT005
+			wantCalls: []string{"AttachRolePolicy"},
+		},
+		"remove only": {
+			remove:    []string{"arn:aws:iam::aws:policy/Old1"}, // lintignore:AWSAT005
+			wantCalls: []string{"DetachRolePolicy"},
+		},
+		"attach error": {
+			add:           []string{"arn:aws:iam::aws:policy/New1"}, // lintignore:AWSAT005
+			remove:        []string{"arn:aws:iam::aws:policy/Old1"}, // lintignore:AWSAT005
+			attachError:   true,
+			wantCalls:     []string{"AttachRolePolicy"},
+			expectedError: regexp.MustCompile(`attaching managed policy \(arn:aws:iam::aws:policy/New1\)`), // lintignore:AWSAT005
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			var calls []string
+			record := func(r *request.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls = append(calls, r.Operation.Name)
+			}
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"AttachRolePolicy": func(r *request.Request) {
+					record(r)
+					if testCase.attachError {
+						r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "Policy does not exist", nil)
+					}
+				},
+				"DetachRolePolicy": record,
+			})
+
+			err := tfiam.UpdateRoleManagedPolicies(ctx, conn, "test-role", aws.StringSlice(testCase.add), aws.StringSlice(testCase.remove), 2*time.Minute)
+
+			if testCase.expectedError == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if testCase.expectedError != nil && (err == nil || !testCase.expectedError.MatchString(err.Error())) {
+				t.Fatalf("got error %v, want error matching %q", err, testCase.expectedError)
+			}
+
+			if !reflect.DeepEqual(calls, testCase.wantCalls) {
+				t.Errorf("got calls %v, want %v", calls, testCase.wantCalls)
+			}
+		})
+	}
+}
+
+func TestUpdateRoleManagedPolicies_atQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	const quota = 10
+
+	attached := make(map[string]bool)
+	for i := 1; i <= quota; i++ {
+		attached[fmt.Sprintf("arn:aws:iam::aws:policy/Old%d", i)] = true // lintignore:AWSAT005
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"AttachRolePolicy": func(r *request.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, r.Operation.Name)
+			if len(attached) >= quota {
+				r.Error = awserr.New(iam.ErrCodeLimitExceededException, "Cannot exceed quota for PoliciesPerRole: 10", nil)
+				return
+			}
+			attached[aws.StringValue(r.Params.(*iam.AttachRolePolicyInput).PolicyArn)] = true
+		},
+		"DetachRolePolicy": func(r *request.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, r.Operation.Name)
+			delete(attached, aws.StringValue(r.Params.(*iam.DetachRolePolicyInput).PolicyArn))
+		},
+	})
+
+	add := []string{"arn:aws:iam::aws:policy/New1"}    // lintignore:AWSAT005
+	remove := []string{"arn:aws:iam::aws:policy/Old1"} // lintignore:AWSAT005
+
+	if err := tfiam.UpdateRoleManagedPolicies(ctx, conn, "test-role", aws.StringSlice(add), aws.StringSlice(remove), 2*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"AttachRolePolicy", "DetachRolePolicy", "AttachRolePolicy"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+
+	if !attached[add[0]] || attached[remove[0]] || len(attached) != quota {
+		t.Errorf("got attached policies %v", attached)
+	}
+}
+
+func TestWaitRoleDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	// The deleted role is still returned by the first reads.
+	var calls int
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"GetRole": func(r *request.Request) {
+			calls++
+
+			if calls <= 2 {
+				r.Data.(*iam.GetRoleOutput).Role = &iam.Role{RoleName: aws.String("test")}
+				return
+			}
+
+			r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "The role with name test cannot be found.", nil)
+		},
+	})
+
+	if err := tfiam.WaitRoleDeleted(ctx, conn, "test"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := calls, 3; got != want {
+		t.Errorf("got %d GetRole calls, want %d", got, want)
+	}
+}
+
+func TestWaitRoleAssumeRolePolicyPropagated(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	const (
+		oldPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+		newPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"lambda.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+		// newPolicyReformatted is equivalent to newPolicy, the way IAM may return it.
+		newPolicyReformatted = `{"Statement":[{"Action":["sts:AssumeRole"],"Principal":{"Service":["lambda.amazonaws.com"]},"Effect":"Allow"}],"Version":"2012-10-17"}`
+	)
+
+	testCases := map[string]struct {
+		policies    []string
+		timeout     time.Duration
+		wantCalls   int
+		expectError bool
+	}{
+		"immediate": {
+			policies:  []string{newPolicyReformatted},
+			timeout:   time.Minute,
+			wantCalls: 1,
+		},
+		"stale then propagated": {
+			policies:  []string{oldPolicy, newPolicy},
+			timeout:   time.Minute,
+			wantCalls: 2,
+		},
+		"timeout": {
+			policies:    []string{oldPolicy},
+			timeout:     2 * time.Second,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetRole": func(r *request.Request) {
+					// The last policy is returned once the others have been.
+					policy := testCase.policies[len(testCase.policies)-1]
+					if calls < len(testCase.policies) {
+						policy = testCase.policies[calls]
+					}
+					calls++
+
+					r.Data.(*iam.GetRoleOutput).Role = &iam.Role{
+						AssumeRolePolicyDocument: aws.String(url.QueryEscape(policy)),
+						RoleName:                 aws.String("test"),
+					}
+				},
+			})
+
+			err := tfiam.WaitRoleAssumeRolePolicyPropagated(ctx, conn, "test", newPolicy, testCase.timeout)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := calls, testCase.wantCalls; got != want {
+				t.Errorf("got %d GetRole calls, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFindRoleByNameRetryingThrottling(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		throttles    int
+		getErr       error
+		wantCalls    int
+		wantNotFound bool
+	}{
+		"throttled then read": {
+			throttles: 2,
+			wantCalls: 3,
+		},
+		"not found": {
+			getErr:       awserr.New(iam.ErrCodeNoSuchEntityException, "The role with name test cannot be found.", nil),
+			wantCalls:    1,
+			wantNotFound: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetRole": func(r *request.Request) {
+					calls++
+
+					switch {
+					case calls <= testCase.throttles:
+						r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+					case testCase.getErr != nil:
+						r.Error = testCase.getErr
+					default:
+						r.Data.(*iam.GetRoleOutput).Role = &iam.Role{RoleName: aws.String("test")}
+					}
+				},
+			})
+
+			role, err := tfiam.FindRoleByNameRetryingThrottling(ctx, conn, "test")
+
+			if got, want := calls, testCase.wantCalls; got != want {
+				t.Errorf("got %d GetRole calls, want %d", got, want)
+			}
+
+			if testCase.wantNotFound {
+				if !tfresource.NotFound(err) {
+					t.Errorf("got error %v, want not found", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := aws.StringValue(role.RoleName), "test"; got != want {
+				t.Errorf("got role %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRoleDeletionProtected(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tags []*iam.Tag
+		want bool
+	}{
+		"no tags": {},
+		"protected": {
+			tags: []*iam.Tag{{Key: aws.String("terraform:protected"), Value: aws.String("true")}},
+			want: true,
+		},
+		"protected upper case": {
+			tags: []*iam.Tag{{Key: aws.String("terraform:protected"), Value: aws.String("TRUE")}},
+			want: true,
+		},
+		"not true": {
+			tags: []*iam.Tag{{Key: aws.String("terraform:protected"), Value: aws.String("false")}},
+		},
+		"other key": {
+			tags: []*iam.Tag{{Key: aws.String("protected"), Value: aws.String("true")}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			role := &iam.Role{RoleName: aws.String("test"), Tags: testCase.tags}
+
+			if got := tfiam.RoleDeletionProtected(role, "terraform:protected"); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRoleDeletionProtectionTagKey(t *testing.T) {
+	t.Parallel()
+
+	if got, want := tfiam.RoleDeletionProtectionTagKey(&conns.AWSClient{IAMRoleDeletionTag: "terraform:protected"}), "terraform:protected"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := tfiam.RoleDeletionProtectionTagKey(nil); got != "" {
+		t.Errorf("got %q, want none", got)
+	}
+}
+
+func TestRoleManagedPoliciesExceedingBoundary(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	documents := map[string]string{
+		"arn:aws:iam::123456789012:policy/boundary": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:Get*","Resource":"*"}]}`,      // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/narrow":   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`, // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/broad":    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,            // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/s3":       `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,         // lintignore:AWSAT005
+	}
+
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"GetPolicy": func(r *request.Request) {
+			r.Data.(*iam.GetPolicyOutput).Policy = &iam.Policy{
+				Arn:              r.Params.(*iam.GetPolicyInput).PolicyArn,
+				DefaultVersionId: aws.String("v1"),
+			}
+		},
+		"GetPolicyVersion": func(r *request.Request) {
+			document, ok := documents[aws.StringValue(r.Params.(*iam.GetPolicyVersionInput).PolicyArn)]
+			if !ok {
+				r.Error = awserr.New(iam.ErrCodeNoSuchEntityException, "Policy does not exist.", nil)
+				return
+			}
+			r.Data.(*iam.GetPolicyVersionOutput).PolicyVersion = &iam.PolicyVersion{Document: aws.String(document)}
+		},
+	})
+
+	got, err := tfiam.RoleManagedPoliciesExceedingBoundary(ctx, conn, "arn:aws:iam::123456789012:policy/boundary", []string{ // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/s3",     // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/narrow", // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/broad",  // lintignore:AWSAT005
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{
+		"arn:aws:iam::123456789012:policy/broad", // lintignore:AWSAT005
+		"arn:aws:iam::123456789012:policy/s3",    // lintignore:AWSAT005
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := tfiam.RoleManagedPoliciesExceedingBoundary(ctx, conn, "arn:aws:iam::123456789012:policy/boundary", []string{"arn:aws:iam::123456789012:policy/missing"}); err == nil { // lintignore:AWSAT005
+		t.Error("expected error for a missing policy, got none")
+	}
+}
+
+func TestRolePermissionsBoundaryName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		boundaryARN string
+		want        string
+	}{
+		"no boundary": {},
+		"AWS managed": {
+			boundaryARN: "arn:aws:iam::aws:policy/PowerUserAccess", // lintignore:AWSAT005
+			want:        "PowerUserAccess",
+		},
+		"customer managed": {
+			boundaryARN: "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			want:        "boundary",
+		},
+		"pathed": {
+			boundaryARN: "arn:aws:iam::123456789012:policy/team/platform/boundary", // lintignore:AWSAT005
+			want:        "boundary",
+		},
+		"other partition": {
+			boundaryARN: "arn:aws-us-gov:iam::123456789012:policy/team/boundary", // lintignore:AWSAT005
+			want:        "boundary",
+		},
+		"invalid": {
+			boundaryARN: "boundary",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.RolePermissionsBoundaryName(testCase.boundaryARN); got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRolePermissionsBoundaryUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		old, new    string
+		wantPuts    int
+		wantDeletes int
+	}{
+		"unchanged": {
+			old: "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			new: "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+		},
+		"same policy with path": {
+			old: "arn:aws:iam::123456789012:policy/team/boundary", // lintignore:AWSAT005
+			new: "arn:aws:iam::123456789012:policy/Boundary",      // lintignore:AWSAT005
+		},
+		"changed": {
+			old:      "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			new:      "arn:aws:iam::123456789012:policy/other",    // lintignore:AWSAT005
+			wantPuts: 1,
+		},
+		"added": {
+			new:      "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			wantPuts: 1,
+		},
+		"removed": {
+			old:         "arn:aws:iam::123456789012:policy/boundary", // lintignore:AWSAT005
+			wantDeletes: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var puts, deletes int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"PutRolePermissionsBoundary": func(r *request.Request) {
+					puts++
+					if got, want := aws.StringValue(r.Params.(*iam.PutRolePermissionsBoundaryInput).PermissionsBoundary), testCase.new; got != want {
+						t.Errorf("PutRolePermissionsBoundary: got %q, want %q", got, want)
+					}
+				},
+				"DeleteRolePermissionsBoundary": func(r *request.Request) {
+					deletes++
+				},
+			})
+
+			if update := tfiam.RolePermissionsBoundaryUpdate(ctx, conn, "test", testCase.old, testCase.new, time.Minute); update != nil {
+				if err := update(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if puts != testCase.wantPuts {
+				t.Errorf("got %d PutRolePermissionsBoundary calls, want %d", puts, testCase.wantPuts)
+			}
+
+			if deletes != testCase.wantDeletes {
+				t.Errorf("got %d DeleteRolePermissionsBoundary calls, want %d", deletes, testCase.wantDeletes)
+			}
+		})
+	}
+}
+
+func TestRolePermissionsBoundaryUpdate_concurrentModification(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	var calls int
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"PutRolePermissionsBoundary": func(r *request.Request) {
+			calls++
+			// Fail the first call as if another update to the role were in progress.
+			if calls == 1 {
+				r.Error = awserr.New(iam.ErrCodeConcurrentModificationException, "Cannot perform the operation because the role is being modified.", nil)
+			}
+		},
+	})
+
+	update := tfiam.RolePermissionsBoundaryUpdate(ctx, conn, "test", "", "arn:aws:iam::123456789012:policy/boundary", time.Minute) // lintignore:AWSAT005
+
+	if err := update(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("got %d PutRolePermissionsBoundary calls, want 2", calls)
+	}
+}
+
+func TestReadRolePermissionsBoundaryDocument(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	document := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:Get*","Resource":"*"}]}`
+
+	testCases := map[string]struct {
+		role      *iam.Role
+		want      string
+		wantCalls int
+	}{
+		"customer managed": {
+			role:      &iam.Role{PermissionsBoundary: &iam.AttachedPermissionsBoundary{PermissionsBoundaryArn: aws.String("arn:aws:iam::123456789012:policy/boundary")}}, // lintignore:AWSAT005
+			want:      document,
+			wantCalls: 2,
+		},
+		"AWS managed": {
+			role:      &iam.Role{PermissionsBoundary: &iam.AttachedPermissionsBoundary{PermissionsBoundaryArn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess")}}, // lintignore:AWSAT005
+			want:      document,
+			wantCalls: 2,
+		},
+		"no boundary": {
+			role: &iam.Role{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"GetPolicy": func(r *request.Request) {
+					calls++
+					if got, want := aws.StringValue(r.Params.(*iam.GetPolicyInput).PolicyArn), aws.StringValue(testCase.role.PermissionsBoundary.PermissionsBoundaryArn); got != want {
+						t.Errorf("GetPolicy: got ARN %q, want %q", got, want)
+					}
+					r.Data.(*iam.GetPolicyOutput).Policy = &iam.Policy{DefaultVersionId: aws.String("v3")}
+				},
+				"GetPolicyVersion": func(r *request.Request) {
+					calls++
+					if got, want := aws.StringValue(r.Params.(*iam.GetPolicyVersionInput).VersionId), "v3"; got != want {
+						t.Errorf("GetPolicyVersion: got version %q, want %q", got, want)
+					}
+					r.Data.(*iam.GetPolicyVersionOutput).PolicyVersion = &iam.PolicyVersion{Document: aws.String(url.QueryEscape(document))}
+				},
+			})
+
+			got, err := tfiam.ReadRolePermissionsBoundaryDocument(ctx, conn, testCase.role)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+
+			if calls != testCase.wantCalls {
+				t.Errorf("got %d API calls, want %d", calls, testCase.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCreateRoleInstanceProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	alreadyExists := awserr.New(iam.ErrCodeEntityAlreadyExistsException, "Instance Profile test already exists.", nil)
+
+	testCases := map[string]struct {
+		createErr   error
+		roles       []*iam.Role
+		wantAdds    int
+		expectError bool
+	}{
+		"new": {
+			wantAdds: 1,
+		},
+		"exists empty": {
+			createErr: alreadyExists,
+			wantAdds:  1,
+		},
+		"exists with role": {
+			createErr: alreadyExists,
+			roles:     []*iam.Role{{RoleName: aws.String("test")}},
+		},
+		"exists with other role": {
+			createErr:   alreadyExists,
+			roles:       []*iam.Role{{RoleName: aws.String("other")}},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var adds int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"CreateInstanceProfile": func(r *request.Request) {
+					if got, want := aws.StringValue(r.Params.(*iam.CreateInstanceProfileInput).Path), "/tf-testing/"; got != want {
+						t.Errorf("Path: got %q, want %q", got, want)
+					}
+					r.Error = testCase.createErr
+				},
+				"GetInstanceProfile": func(r *request.Request) {
+					r.Data.(*iam.GetInstanceProfileOutput).InstanceProfile = &iam.InstanceProfile{
+						InstanceProfileName: aws.String("test"),
+						Roles:               testCase.roles,
+					}
+				},
+				"AddRoleToInstanceProfile": func(r *request.Request) {
+					adds++
+				},
+			})
+
+			err := tfiam.CreateRoleInstanceProfile(ctx, conn, "test", "/tf-testing/", 2*time.Minute)
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := adds, testCase.wantAdds; got != want {
+				t.Errorf("got %d AddRoleToInstanceProfile calls, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteRoleInstanceProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	noSuchEntity := awserr.New(iam.ErrCodeNoSuchEntityException, "Instance Profile test cannot be found.", nil)
+
+	testCases := map[string]struct {
+		removeErr   error
+		deleteErr   error
+		expectError bool
+	}{
+		"deleted": {},
+		"not found": {
+			removeErr: noSuchEntity,
+			deleteErr: noSuchEntity,
+		},
+		"delete error": {
+			deleteErr:   awserr.New(iam.ErrCodeDeleteConflictException, "Cannot delete entity, must remove roles from instance profile first.", nil),
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var operations []string
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"RemoveRoleFromInstanceProfile": func(r *request.Request) {
+					operations = append(operations, r.Operation.Name)
+					r.Error = testCase.removeErr
+				},
+				"DeleteInstanceProfile": func(r *request.Request) {
+					operations = append(operations, r.Operation.Name)
+					r.Error = testCase.deleteErr
+				},
+			})
+
+			err := tfiam.DeleteRoleInstanceProfile(ctx, conn, "test")
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if want := []string{"RemoveRoleFromInstanceProfile", "DeleteInstanceProfile"}; !reflect.DeepEqual(operations, want) {
+				t.Errorf("got operations %v, want %v", operations, want)
+			}
+		})
+	}
+}
+
+func TestDeleteRoleInstanceProfiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	testCases := map[string]struct {
+		removeErrs  []error
+		wantRemoves int
+		expectError bool
+	}{
+		"success": {
+			wantRemoves: 2,
+		},
+		"delete conflict retried": {
+			removeErrs:  []error{awserr.New(iam.ErrCodeDeleteConflictException, "Cannot remove role from instance profile while it is being modified.", nil)},
+			wantRemoves: 3,
+		},
+		"no such entity skipped": {
+			removeErrs:  []error{awserr.New(iam.ErrCodeNoSuchEntityException, "Instance Profile test-1 cannot be found.", nil)},
+			wantRemoves: 2,
+		},
+		"other error": {
+			removeErrs:  []error{awserr.New(iam.ErrCodeServiceFailureException, "test", nil)},
+			wantRemoves: 1,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var removes int
+			conn := newMockIAMConn(t, map[string]func(*request.Request){
+				"ListInstanceProfilesForRole": func(r *request.Request) {
+					r.Data.(*iam.ListInstanceProfilesForRoleOutput).InstanceProfiles = []*iam.InstanceProfile{
+						{InstanceProfileName: aws.String("test-1")},
+						{InstanceProfileName: aws.String("test-2")},
+					}
+				},
+				"RemoveRoleFromInstanceProfile": func(r *request.Request) {
+					if removes < len(testCase.removeErrs) {
+						r.Error = testCase.removeErrs[removes]
+					}
+					removes++
+				},
+			})
+
+			err := tfiam.DeleteRoleInstanceProfiles(ctx, conn, "test", 2*time.Minute)
+
+			if got := err != nil; got != testCase.expectError {
+				t.Errorf("got error %v, expected error: %t", err, testCase.expectError)
+			}
+
+			if removes != testCase.wantRemoves {
+				t.Errorf("got %d RemoveRoleFromInstanceProfile calls, want %d", removes, testCase.wantRemoves)
+			}
+		})
+	}
+}
+
+func TestIsRoleDetachRetryable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "delete conflict",
+			err:  awserr.New(iam.ErrCodeDeleteConflictException, "Cannot detach a policy while it is being attached.", nil),
+			want: true,
+		},
+		{
+			name: "throttling",
+			err:  awserr.New("Throttling", "Rate exceeded", nil),
+			want: true,
+		},
+		{
+			name: "no such entity",
+			err:  awserr.New(iam.ErrCodeNoSuchEntityException, "Policy was not found.", nil),
+		},
+		{
+			name: "concurrent modification",
+			err:  awserr.New(iam.ErrCodeConcurrentModificationException, "Cannot perform the operation because the role is being modified.", nil),
+			want: true,
+		},
+		{
+			name: "instance profile delete conflict",
+			err:  awserr.New(iam.ErrCodeDeleteConflictException, "Cannot remove role from instance profile while it is being modified.", nil),
+			want: true,
+		},
+		{
+			name: "instance profile no such entity",
+			err:  awserr.New(iam.ErrCodeNoSuchEntityException, "Instance Profile test cannot be found.", nil),
+		},
+		{
+			name: "other",
+			err:  errors.New("test"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfiam.IsRoleDetachRetryable(testCase.err); got != testCase.want {
+				t.Errorf("IsRoleDetachRetryable = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func testRoleResourceDataWithRawConfig(attrs map[string]cty.Value, isNew bool) *schema.ResourceData {
+	r := tfiam.ResourceRole()
+
+	rawConfig := cty.NullVal(r.CoreConfigSchema().ImpliedType())
+	if attrs != nil {
+		vals := make(map[string]cty.Value)
+		for k, ty := range rawConfig.Type().AttributeTypes() {
+			if v, ok := attrs[k]; ok {
+				vals[k] = v
+			} else {
+				vals[k] = cty.NullVal(ty)
+			}
+		}
+		rawConfig = cty.ObjectVal(vals)
+	}
+
+	d := r.Data(&sdkterraform.InstanceState{ID: "test", RawConfig: rawConfig})
+	if isNew {
+		d.MarkNewResource()
+	}
+
+	return d
+}
+
+func TestRoleReadSkipsPolicies(t *testing.T) {
+	t.Parallel()
+
+	inlinePolicyType := tfiam.ResourceRole().CoreConfigSchema().ImpliedType().AttributeType("inline_policy")
+
+	testCases := map[string]struct {
+		attrs map[string]cty.Value
+		isNew bool
+		want  bool
+	}{
+		"neither configured": {
+			attrs: map[string]cty.Value{
+				"name":          cty.StringVal("test"),
+				"inline_policy": cty.SetValEmpty(inlinePolicyType.ElementType()),
+			},
+			want: true,
+		},
+		"new resource": {
+			attrs: map[string]cty.Value{
+				"name": cty.StringVal("test"),
+			},
+			isNew: true,
+		},
+		"refresh with null config": {},
+		"empty managed_policy_arns": {
+			attrs: map[string]cty.Value{
+				"name":                cty.StringVal("test"),
+				"managed_policy_arns": cty.SetValEmpty(cty.String),
+			},
+		},
+		"managed_policy_names configured": {
+			attrs: map[string]cty.Value{
+				"name":                 cty.StringVal("test"),
+				"managed_policy_names": cty.SetVal([]cty.Value{cty.StringVal("test")}),
+			},
+		},
+		"inline_policy configured": {
+			attrs: map[string]cty.Value{
+				"name": cty.StringVal("test"),
+				"inline_policy": cty.SetVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"name":   cty.StringVal("test"),
+					"policy": cty.StringVal(`{}`),
+				})}),
+			},
+		},
+		"inline_policy unknown": {
+			attrs: map[string]cty.Value{
+				"name":          cty.StringVal("test"),
+				"inline_policy": cty.UnknownVal(inlinePolicyType),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := testRoleResourceDataWithRawConfig(testCase.attrs, testCase.isNew)
+
+			if got := tfiam.RoleReadSkipsPolicies(d); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+// testReadRoleInlinePoliciesConn returns a stubbed IAM client for a role with the specified number of inline policies,
+// returned 100 per page. GetRolePolicy takes latency and reports the maximum number of concurrent calls in maxInFlight.
+func testReadRoleInlinePoliciesConn(t testing.TB, policies int, latency time.Duration, maxInFlight *int32) *iam.IAM {
+	t.Helper()
+
+	const pageSize = 100
+
+	var inFlight int32
+	return newMockIAMConn(t, map[string]func(*request.Request){
+		"ListRolePolicies": func(r *request.Request) {
+			data := r.Data.(*iam.ListRolePoliciesOutput)
+			page, _ := strconv.Atoi(aws.StringValue(r.Params.(*iam.ListRolePoliciesInput).Marker))
+			for i := page * pageSize; i < policies && i < (page+1)*pageSize; i++ {
+				data.PolicyNames = append(data.PolicyNames, aws.String(fmt.Sprintf("policy-%04d", i)))
+			}
+			if (page+1)*pageSize < policies {
+				data.IsTruncated = aws.Bool(true)
+				data.Marker = aws.String(strconv.Itoa(page + 1))
+			}
+		},
+		"GetRolePolicy": func(r *request.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				if m := atomic.LoadInt32(maxInFlight); n <= m || atomic.CompareAndSwapInt32(maxInFlight, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(latency)
+
+			data := r.Data.(*iam.GetRolePolicyOutput)
+			name := aws.StringValue(r.Params.(*iam.GetRolePolicyInput).PolicyName)
+			data.PolicyName = aws.String(name)
+			data.PolicyDocument = aws.String(url.QueryEscape(fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":%q,"Effect":"Allow","Action":"s3:ListBucket","Resource":"*"}]}`, strings.ReplaceAll(name, "-", ""))))
+		},
+	})
+}
+
+func TestRefreshRoleInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	const document = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:ListBucket","Resource":"*"}]}`
+
+	// newConn returns a client for a role with three inline policies, where reading failPolicyName fails.
+	newConn := func(t *testing.T, failPolicyName string) *iam.IAM {
+		return newMockIAMConn(t, map[string]func(*request.Request){
+			"ListRolePolicies": func(r *request.Request) {
+				r.Data.(*iam.ListRolePoliciesOutput).PolicyNames = aws.StringSlice([]string{"policy-a", "policy-b", "policy-c"})
+			},
+			"GetRolePolicy": func(r *request.Request) {
+				if aws.StringValue(r.Params.(*iam.GetRolePolicyInput).PolicyName) == failPolicyName {
+					r.Error = awserr.New("AccessDenied", "User is not authorized to perform: iam:GetRolePolicy", nil)
+					return
+				}
+				r.Data.(*iam.GetRolePolicyOutput).PolicyDocument = aws.String(url.QueryEscape(document))
+			},
+		})
+	}
+
+	testCases := map[string]struct {
+		failPolicyName     string
+		partialReadOnError bool
+		wantError          bool
+		wantWarnings       int
+		wantInlinePolicies int
+	}{
+		"success": {
+			wantInlinePolicies: 3,
+		},
+		"failure": {
+			failPolicyName: "policy-b",
+			wantError:      true,
+		},
+		"failure with partial read": {
+			failPolicyName:     "policy-b",
+			partialReadOnError: true,
+			wantWarnings:       1,
+			wantInlinePolicies: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, tfiam.ResourceRole().SchemaMap(), map[string]interface{}{
+				"name":                  "test",
+				"partial_read_on_error": testCase.partialReadOnError,
+				"inline_policy": []interface{}{
+					map[string]interface{}{"name": "policy-a", "policy": document},
+				},
+			})
+			d.SetId("test")
+
+			diags := tfiam.RefreshRoleInlinePolicies(ctx, newConn(t, testCase.failPolicyName), d, "test")
+
+			if got, want := diags.HasError(), testCase.wantError; got != want {
+				t.Fatalf("got error %t, want %t: %v", got, want, diags)
+			}
+
+			if testCase.wantError {
+				return
+			}
+
+			if got, want := len(diags), testCase.wantWarnings; got != want {
+				t.Errorf("got %d warnings, want %d: %v", got, want, diags)
+			}
+
+			for _, d := range diags {
+				if d.Severity != diag.Warning || !strings.Contains(d.Summary, "policy-b") {
+					t.Errorf("unexpected diagnostic: %v", d)
+				}
+			}
+
+			// After a partial read, the inline_policy from state is kept.
+			if got, want := d.Get("inline_policy").(*schema.Set).Len(), testCase.wantInlinePolicies; got != want {
+				t.Errorf("got %d inline policies, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestReadRoleInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	const policies = 250
+
+	var maxInFlight int32
+	conn := testReadRoleInlinePoliciesConn(t, policies, time.Millisecond, &maxInFlight)
+
+	apiObjects, err := tfiam.ReadRoleInlinePolicies(ctx, conn, "test")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := len(apiObjects), policies; got != want {
+		t.Fatalf("got %d inline policies, want %d", got, want)
+	}
+
+	// The policies are in listing order, each with its own document.
+	for i, apiObject := range apiObjects {
+		name := fmt.Sprintf("policy-%04d", i)
+
+		if got := aws.StringValue(apiObject.PolicyName); got != name {
+			t.Errorf("inline policy %d: got name %q, want %q", i, got, name)
+		}
+
+		if got, want := aws.StringValue(apiObject.PolicyDocument), strings.ReplaceAll(name, "-", ""); !strings.Contains(got, want) {
+			t.Errorf("inline policy (%s): document %s does not contain %q", name, got, want)
+		}
+	}
+
+	if got, want := atomic.LoadInt32(&maxInFlight), int32(5); got > want {
+		t.Errorf("got %d concurrent GetRolePolicy calls, want at most %d", got, want)
+	} else if got < 2 {
+		t.Errorf("got %d concurrent GetRolePolicy calls, want the reads to run concurrently", got)
+	}
+}
+
+func TestReadRoleInlinePolicies_errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := acctest.Context(t)
+
+	var mu sync.Mutex
+	throttled := make(map[string]bool)
+
+	// Every read is throttled once, and policy-b then fails.
+	conn := newMockIAMConn(t, map[string]func(*request.Request){
+		"ListRolePolicies": func(r *request.Request) {
+			r.Data.(*iam.ListRolePoliciesOutput).PolicyNames = aws.StringSlice([]string{"policy-a", "policy-b", "policy-c"})
+		},
+		"GetRolePolicy": func(r *request.Request) {
+			name := aws.StringValue(r.Params.(*iam.GetRolePolicyInput).PolicyName)
+
+			mu.Lock()
+			retry := !throttled[name]
+			throttled[name] = true
+			mu.Unlock()
+
+			switch {
+			case retry:
+				r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+			case name == "policy-b":
+				r.Error = awserr.New("AccessDenied", "User is not authorized to perform: iam:GetRolePolicy", nil)
+			default:
+				r.Data.(*iam.GetRolePolicyOutput).PolicyDocument = aws.String(url.QueryEscape(`{"Version":"2012-10-17","Statement":[]}`))
+			}
+		},
+	})
+
+	_, err := tfiam.ReadRoleInlinePolicies(ctx, conn, "test")
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if got, want := err.Error(), "reading inline policy (policy-b)"; !strings.Contains(got, want) {
+		t.Errorf("error %q does not contain %q", got, want)
+	}
+
+	for _, name := range []string{"policy-a", "policy-c"} {
+		if got := err.Error(); strings.Contains(got, name) {
+			t.Errorf("error %q mentions %s, whose throttled read should have been retried", got, name)
+		}
+	}
+}
+
+// BenchmarkReadRoleInlinePolicies reads 500 inline policies, where each GetRolePolicy call takes 1ms.
+// Serial reads take at least 500ms per operation.
+func BenchmarkReadRoleInlinePolicies(b *testing.B) {
+	ctx := context.Background()
+
+	var maxInFlight int32
+	conn := testReadRoleInlinePoliciesConn(b, 500, time.Millisecond, &maxInFlight)
+
+	for n := 0; n < b.N; n++ {
+		if _, err := tfiam.ReadRoleInlinePolicies(ctx, conn, "test"); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+// BenchmarkRoleReadPolicies reports the number of policy listing API calls made by Read
+// for a role with 1,000 inline and 1,000 attached policies, returned 100 per page.
+func BenchmarkRoleReadPolicies(b *testing.B) {
+	ctx := context.Background()
+
+	const (
+		pageSize = 100
+		pages    = 10
+	)
+
+	var calls int
+	conn := newMockIAMConn(b, map[string]func(*request.Request){
+		"ListRolePolicies": func(r *request.Request) {
+			calls++
+
+			data := r.Data.(*iam.ListRolePoliciesOutput)
+			page, _ := strconv.Atoi(aws.StringValue(r.Params.(*iam.ListRolePoliciesInput).Marker))
+			for i := 0; i < pageSize; i++ {
+				data.PolicyNames = append(data.PolicyNames, aws.String(fmt.Sprintf("policy-%d-%d", page, i)))
+			}
+			if page+1 < pages {
+				data.IsTruncated = aws.Bool(true)
+				data.Marker = aws.String(strconv.Itoa(page + 1))
+			}
+		},
+		"ListAttachedRolePolicies": func(r *request.Request) {
+			calls++
+
+			data := r.Data.(*iam.ListAttachedRolePoliciesOutput)
+			page, _ := strconv.Atoi(aws.StringValue(r.Params.(*iam.ListAttachedRolePoliciesInput).Marker))
+			for i := 0; i < pageSize; i++ {
+				data.AttachedPolicies = append(data.AttachedPolicies, &iam.AttachedPolicy{PolicyArn: aws.String(fmt.Sprintf("arn:aws:iam::123456789012:policy/policy-%d-%d", page, i))}) // lintignore:AWSAT005
+			}
+			if page+1 < pages {
+				data.IsTruncated = aws.Bool(true)
+				data.Marker = aws.String(strconv.Itoa(page + 1))
+			}
+		},
+	})
+
+	benchmarks := map[string]map[string]cty.Value{
+		"refresh": nil,
+		"after update without policy arguments": {
+			"name": cty.StringVal("test"),
+		},
+	}
+
+	for name, attrs := range benchmarks {
+		attrs := attrs
+		b.Run(name, func(b *testing.B) {
+			d := testRoleResourceDataWithRawConfig(attrs, false)
+			calls = 0
+
+			for n := 0; n < b.N; n++ {
+				if tfiam.RoleReadSkipsPolicies(d) {
+					continue
+				}
+
+				if _, err := tfiam.ReadRolePolicyNames(ctx, conn, "test"); err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := tfiam.ReadRolePolicyAttachments(ctx, conn, "test"); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(calls)/float64(b.N), "calls/op")
+		})
+	}
+}
+
+func TestRunRoleUpdates(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	applied := make(map[string]bool)
+
+	update := func(name string, err error) func() error {
+		return func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			applied[name] = true
+			return err
+		}
+	}
+
+	err := tfiam.RunRoleUpdates([]func() error{
+		update("assume_role_policy", nil),
+		update("description", errors.New("description failed")),
+		update("max_session_duration", nil),
+		update("tags", errors.New("tags failed")),
+	})
+
+	for _, name := range []string{"assume_role_policy", "description", "max_session_duration", "tags"} {
+		if !applied[name] {
+			t.Errorf("expected update %q to be applied", name)
+		}
+	}
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	for _, want := range []string{"description failed", "tags failed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got: %s", want, err)
+		}
+	}
+
+	if err := tfiam.RunRoleUpdates(nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
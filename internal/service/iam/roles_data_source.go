@@ -40,6 +40,10 @@ func DataSourceRoles() *schema.Resource {
 			"path_prefix": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 512),
+					validation.StringMatch(regexp.MustCompile(`^/[\x21-\x7E]*$`), "must begin with a forward slash (/)"),
+				),
 			},
 		},
 	}
@@ -55,6 +59,11 @@ func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, meta inter
 		input.PathPrefix = aws.String(v.(string))
 	}
 
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
 	var results []*iam.Role
 
 	err := conn.ListRolesPagesWithContext(ctx, input, func(page *iam.ListRolesOutput, lastPage bool) bool {
@@ -67,7 +76,7 @@ func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, meta inter
 				continue
 			}
 
-			if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(aws.StringValue(role.RoleName)) {
+			if nameRegex != nil && !nameRegex.MatchString(aws.StringValue(role.RoleName)) {
 				continue
 			}
 
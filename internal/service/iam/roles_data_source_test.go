@@ -122,6 +122,48 @@ func TestAccIAMRolesDataSource_nameRegexAndPathPrefix(t *testing.T) {
 	})
 }
 
+func TestAccIAMRolesDataSource_pathPrefixMultiplePaths(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	rPathPrefix := sdkacctest.RandomWithPrefix("tf-acc-path")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRolesDataSourceConfig_pathPrefixMultiplePaths(rName, rPathPrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aws_iam_roles.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.aws_iam_roles.all", "arns.#", "3"),
+					resource.TestCheckResourceAttr("data.aws_iam_roles.service", "names.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.aws_iam_roles.service", "names.*", "aws_iam_role.service_a", "name"),
+					resource.TestCheckTypeSetElemAttrPair("data.aws_iam_roles.service", "names.*", "aws_iam_role.service_b", "name"),
+					resource.TestCheckResourceAttr("data.aws_iam_roles.service_a", "names.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("data.aws_iam_roles.service_a", "arns.*", "aws_iam_role.service_a", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIAMRolesDataSource_pathPrefixInvalid(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ErrorCheck:               acctest.ErrorCheck(t, iam.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRolesDataSourceConfig_pathPrefixInvalid,
+				ExpectError: regexp.MustCompile(`must begin with a forward slash`),
+			},
+		},
+	})
+}
+
 const testAccRolesDataSourceConfig_basic = `
 data "aws_iam_roles" "test" {}
 `
@@ -235,3 +277,62 @@ data "aws_iam_roles" "test" {
 }
 `, rCount, rName, rPathPrefix, rIndex)
 }
+
+func testAccRolesDataSourceConfig_pathPrefixMultiplePaths(rName, rPathPrefix string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+locals {
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "ec2.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+}
+
+resource "aws_iam_role" "service_a" {
+  name               = "%[1]s-service-a"
+  path               = "/%[2]s/service-role/a/"
+  assume_role_policy = local.assume_role_policy
+}
+
+resource "aws_iam_role" "service_b" {
+  name               = "%[1]s-service-b"
+  path               = "/%[2]s/service-role/b/"
+  assume_role_policy = local.assume_role_policy
+}
+
+resource "aws_iam_role" "other" {
+  name               = "%[1]s-other"
+  path               = "/%[2]s/other/"
+  assume_role_policy = local.assume_role_policy
+}
+
+data "aws_iam_roles" "all" {
+  path_prefix = "/%[2]s/"
+
+  depends_on = [aws_iam_role.service_a, aws_iam_role.service_b, aws_iam_role.other]
+}
+
+data "aws_iam_roles" "service" {
+  path_prefix = "/%[2]s/service-role/"
+
+  depends_on = [aws_iam_role.service_a, aws_iam_role.service_b, aws_iam_role.other]
+}
+
+data "aws_iam_roles" "service_a" {
+  path_prefix = aws_iam_role.service_a.path
+}
+`, rName, rPathPrefix)
+}
+
+const testAccRolesDataSourceConfig_pathPrefixInvalid = `
+data "aws_iam_roles" "test" {
+  path_prefix = "service-role/"
+}
+`
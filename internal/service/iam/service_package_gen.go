@@ -65,6 +65,18 @@ func (p *servicePackage) SDKDataSources(ctx context.Context) []*types.ServicePac
 			Factory:  DataSourceRole,
 			TypeName: "aws_iam_role",
 		},
+		{
+			Factory:  DataSourceRoleExport,
+			TypeName: "aws_iam_role_export",
+		},
+		{
+			Factory:  DataSourceRoleInlinePolicies,
+			TypeName: "aws_iam_role_inline_policies",
+		},
+		{
+			Factory:  DataSourceRoleTrustRelationship,
+			TypeName: "aws_iam_role_trust_relationship",
+		},
 		{
 			Factory:  DataSourceRoles,
 			TypeName: "aws_iam_roles",
@@ -162,6 +174,10 @@ func (p *servicePackage) SDKResources(ctx context.Context) []*types.ServicePacka
 			Factory:  ResourceRolePolicyAttachment,
 			TypeName: "aws_iam_role_policy_attachment",
 		},
+		{
+			Factory:  ResourceRolePolicyAttachmentsExclusive,
+			TypeName: "aws_iam_role_policy_attachments_exclusive",
+		},
 		{
 			Factory:  ResourceSAMLProvider,
 			TypeName: "aws_iam_saml_provider",
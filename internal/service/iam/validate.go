@@ -4,25 +4,213 @@
 package iam
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+	"golang.org/x/exp/slices"
 )
 
 var validRolePolicyName = validResourceName(rolePolicyNameMaxLen)
 
+var resourceNameRegexp = regexp.MustCompile(`^[\w+=,.@-]*$`)
+
 func validResourceName(max int) schema.SchemaValidateFunc {
 	return validation.All(
 		validation.StringLenBetween(1, max),
-		validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]*$`), "must match [\\w+=,.@-]"),
+		validation.StringMatch(resourceNameRegexp, "must match [\\w+=,.@-]"),
+	)
+}
+
+// validRoleName validates an IAM role name or name prefix.
+// A slash usually means that the role's path has been included in the name, so that case gets its own error.
+func validRoleName(max int) schema.SchemaValidateFunc {
+	return validation.All(
+		validation.StringLenBetween(1, max),
+		func(v interface{}, k string) (ws []string, es []error) {
+			if strings.Contains(v.(string), "/") {
+				es = append(es, fmt.Errorf("%q must not contain a slash (/), a role's path belongs in the path argument (for example path = \"/division/\" and name = \"role-name\")", k))
+				return
+			}
+			return validation.StringMatch(resourceNameRegexp, "must match [\\w+=,.@-]")(v, k)
+		},
 	)
 }
 
+// roleNameReservedPrefixes are prefixes of names that AWS gives to the roles it creates, with the way to manage such a role.
+var roleNameReservedPrefixes = []struct {
+	prefix   string
+	guidance string
+}{
+	{"aws-service-role", "service-linked roles are created with the aws_iam_service_linked_role resource"},
+	{"AWSServiceRoleFor", "service-linked roles are created with the aws_iam_service_linked_role resource"},
+	{"AWSReservedSSO_", "IAM Identity Center creates the roles for its permission sets, which are managed with the aws_ssoadmin_permission_set resource"},
+}
+
+// validRoleNameNotReserved warns about a role name or name prefix that starts with a prefix AWS uses for roles it creates.
+// IAM role names are case-insensitive, so the prefixes are too.
+func validRoleNameNotReserved(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		return
+	}
+
+	for _, reserved := range roleNameReservedPrefixes {
+		if strings.HasPrefix(strings.ToLower(value), strings.ToLower(reserved.prefix)) {
+			ws = append(ws, fmt.Sprintf("%q (%s) starts with %s, which AWS uses for roles that it creates; %s", k, value, reserved.prefix, reserved.guidance))
+			return
+		}
+	}
+
+	return
+}
+
+// rolePolicyDocumentMaxLen is IAM's limit on the size of an inline role policy document, which excludes whitespace.
+const rolePolicyDocumentMaxLen = 10240
+
+// validRoleInlinePolicyDocument validates an inline role policy document, including its size once whitespace is removed.
+var validRoleInlinePolicyDocument = validation.All(
+	verify.ValidIAMPolicyJSON,
+	func(v interface{}, k string) (ws []string, es []error) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(v.(string))); err != nil {
+			// verify.ValidIAMPolicyJSON will already have returned an error for invalid JSON
+			return
+		}
+		if n := buf.Len(); n > rolePolicyDocumentMaxLen {
+			es = append(es, fmt.Errorf("%q is %d characters without whitespace, exceeding the IAM limit of %d for inline role policies", k, n, rolePolicyDocumentMaxLen))
+		}
+		return
+	},
+)
+
+const (
+	policyVersion2008_10_17 = "2008-10-17"
+	policyVersion2012_10_17 = "2012-10-17"
+)
+
+// validRoleAssumeRolePolicy validates a trust policy document. It must be a JSON object with a
+// Version that IAM recognizes and a Statement, which is an array of statements or a single statement.
+// The legacy 2008-10-17 version, which does not support policy variables, is accepted with a warning,
+// as is a policy that trusts only services that use service-linked roles, or one that lets any AWS principal
+// assume the role without a condition.
+var validRoleAssumeRolePolicy = validation.All(
+	validation.StringIsJSON,
+	func(v interface{}, k string) (ws []string, es []error) {
+		value := []byte(v.(string))
+		if !json.Valid(value) {
+			// validation.StringIsJSON will already have returned an error for invalid JSON
+			return
+		}
+
+		var policy map[string]json.RawMessage
+		if err := json.Unmarshal(value, &policy); err != nil {
+			es = append(es, fmt.Errorf("%q must be a JSON object", k))
+			return
+		}
+
+		var version string
+		if raw, ok := policy["Version"]; !ok {
+			es = append(es, fmt.Errorf("%q must have a Version, use %q", k, policyVersion2012_10_17))
+		} else if err := json.Unmarshal(raw, &version); err != nil || (version != policyVersion2012_10_17 && version != policyVersion2008_10_17) {
+			es = append(es, fmt.Errorf("%q has Version %s, which is not one of %q or %q", k, raw, policyVersion2012_10_17, policyVersion2008_10_17))
+		} else if version == policyVersion2008_10_17 {
+			ws = append(ws, fmt.Sprintf("%q has the legacy Version %q, which does not support policy variables; use %q", k, policyVersion2008_10_17, policyVersion2012_10_17))
+		}
+
+		if raw, ok := policy["Statement"]; !ok {
+			es = append(es, fmt.Errorf("%q must have a Statement", k))
+		} else if raw := bytes.TrimSpace(raw); len(raw) == 0 || (raw[0] != '[' && raw[0] != '{') {
+			es = append(es, fmt.Errorf("%q Statement must be an array of statements or a single statement object", k))
+		}
+
+		return
+	},
+	func(v interface{}, k string) (ws []string, es []error) {
+		// Policies that cannot be parsed have already been reported.
+		services, err := roleServiceLinkedOnlyPrincipals(v.(string))
+		if err != nil || len(services) == 0 {
+			return
+		}
+
+		ws = append(ws, fmt.Sprintf("%q trusts only %s, which use service-linked roles and do not assume roles that you create; use the aws_iam_service_linked_role resource instead", k, strings.Join(services, ", ")))
+
+		return
+	},
+	func(v interface{}, k string) (ws []string, es []error) {
+		// Policies that cannot be parsed have already been reported.
+		statements, err := roleWildcardPrincipalStatements(v.(string))
+		if err != nil || len(statements) == 0 {
+			return
+		}
+
+		ws = append(ws, fmt.Sprintf("%q lets any AWS principal assume the role in statement %s, which has no Condition; name the trusted principals, or add a condition such as aws:PrincipalOrgID", k, strings.Join(statements, ", ")))
+
+		return
+	},
+)
+
+// roleWildcardPrincipalStatements returns the Sid, or the index if there is none, of each Allow statement in a trust
+// policy whose principal is "*" or {"AWS": "*"} and that has no Condition. A condition usually narrows such a
+// statement, e.g. to an organization, so those statements are not reported.
+func roleWildcardPrincipalStatements(policy string) ([]string, error) {
+	var doc IAMPolicyDoc
+
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, err
+	}
+
+	var statements []string
+
+	for i, statement := range doc.Statements {
+		if statement == nil || !strings.EqualFold(statement.Effect, "Allow") || len(statement.Conditions) > 0 {
+			continue
+		}
+
+		for _, principal := range statement.Principals {
+			if (principal.Type == "*" || principal.Type == "AWS") && slices.Contains(policyStatementValues(principal.Identifiers), "*") {
+				if statement.Sid != "" {
+					statements = append(statements, statement.Sid)
+				} else {
+					statements = append(statements, strconv.Itoa(i))
+				}
+				break
+			}
+		}
+	}
+
+	return statements, nil
+}
+
+// validRoleInlinePolicies validates a map of inline role policy names to policy documents.
+func validRoleInlinePolicies(v interface{}, k string) (ws []string, es []error) {
+	for name, policy := range v.(map[string]interface{}) {
+		key := fmt.Sprintf("%s[%q]", k, name)
+
+		w, e := validRolePolicyName(name, key)
+		ws, es = append(ws, w...), append(es, e...)
+
+		policy, ok := policy.(string)
+		if !ok {
+			continue
+		}
+
+		w, e = validRoleInlinePolicyDocument(policy, key)
+		ws, es = append(ws, w...), append(es, e...)
+	}
+
+	return
+}
+
 var validAccountAlias = validation.All(
 	validation.StringLenBetween(3, 63),
 	validation.StringMatch(regexp.MustCompile(`^[a-z0-9][a-z0-9-]+$`), "must start with an alphanumeric character and only contain lowercase alphanumeric characters and hyphens"),
@@ -54,6 +242,40 @@ var validOpenIDURL = validation.All(
 	},
 )
 
+// validRoleMaxSessionDuration validates a role's maximum session duration. A duration longer than one hour
+// is accepted with a warning, as STS caps sessions from role chaining at one hour.
+var validRoleMaxSessionDuration = validation.All(
+	validation.IntBetween(roleMaxSessionDurationMin, roleMaxSessionDurationMax),
+	func(v interface{}, k string) (ws []string, es []error) {
+		if value, ok := v.(int); ok && value > roleChainingMaxSessionDuration {
+			ws = append(ws, fmt.Sprintf("%q (%d) is longer than %d seconds; if another IAM role assumes this role (role chaining), its sessions are capped at %d seconds", k, value, roleChainingMaxSessionDuration, roleChainingMaxSessionDuration))
+		}
+		return
+	},
+)
+
+// validRolePath validates an IAM role path, which must begin and end with a forward slash.
+// IAM cannot move a role to a different path, so catching mistakes at plan time avoids a needless replacement.
+var validRolePath = validation.All(
+	validation.StringLenBetween(0, 512),
+	validation.StringMatch(regexp.MustCompile(`^/([\x21-\x7E]+/)?$`), "must begin and end with a forward slash (/)"),
+)
+
+// validRolePathNormalized validates a role path like validRolePath, but only warns about a missing trailing slash
+// because normalizeRolePath adds it before the path is planned or sent to IAM.
+func validRolePathNormalized(v interface{}, k string) (ws []string, es []error) {
+	if value, ok := v.(string); ok {
+		if normalized := normalizeRolePath(value); normalized != value {
+			ws = append(ws, fmt.Sprintf("%q (%s) does not end with a forward slash (/), so %s is used", k, value, normalized))
+			v = normalized
+		}
+	}
+
+	w, e := validRolePath(v, k)
+
+	return append(ws, w...), append(es, e...)
+}
+
 var validRolePolicyRole = validation.All(
 	validation.StringLenBetween(1, 128),
 	validation.StringMatch(regexp.MustCompile(`[\w+=,.@-]+`), ""),
@@ -64,3 +286,13 @@ var validRolePolicyRole = validation.All(
 		return
 	},
 )
+
+// validPolicyARN validates an IAM managed policy ARN. The empty string is accepted.
+var validPolicyARN = verify.ValidARNCheck(policyARNCheck)
+
+func policyARNCheck(v any, k string, arn arn.ARN) (ws []string, errors []error) {
+	if arn.Service != iam.ServiceName || !strings.HasPrefix(arn.Resource, "policy/") {
+		errors = append(errors, fmt.Errorf("%q (%s) is not a valid IAM Policy ARN", k, v))
+	}
+	return
+}
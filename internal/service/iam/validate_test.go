@@ -4,7 +4,12 @@
 package iam
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 )
 
 func TestValidRoleProfileName(t *testing.T) {
@@ -34,6 +39,66 @@ func TestValidRoleProfileName(t *testing.T) {
 	}
 }
 
+func TestValidRolePolicyNameLength(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		validate func(interface{}, string) ([]string, []error)
+		value    string
+		valid    bool
+	}{
+		{
+			name:     "name at limit",
+			validate: validRolePolicyName,
+			value:    strings.Repeat("a", rolePolicyNameMaxLen),
+			valid:    true,
+		},
+		{
+			name:     "name over limit",
+			validate: validRolePolicyName,
+			value:    strings.Repeat("a", rolePolicyNameMaxLen+1),
+			valid:    false,
+		},
+		{
+			name:     "name with invalid character",
+			validate: validRolePolicyName,
+			value:    "policy/name",
+			valid:    false,
+		},
+		{
+			name:     "prefix at limit",
+			validate: validResourceName(rolePolicyNamePrefixMaxLen),
+			value:    strings.Repeat("a", rolePolicyNamePrefixMaxLen),
+			valid:    true,
+		},
+		{
+			name:     "prefix over limit",
+			validate: validResourceName(rolePolicyNamePrefixMaxLen),
+			value:    strings.Repeat("a", rolePolicyNamePrefixMaxLen+1),
+			valid:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, errs := testCase.validate(testCase.value, "name")
+
+			if got := len(errs) == 0; got != testCase.valid {
+				t.Errorf("%q: got valid %t, want %t: %v", testCase.value, got, testCase.valid, errs)
+			}
+		})
+	}
+
+	// A generated name is the prefix plus the unique ID suffix and must fit IAM's limit.
+	if got := len(id.PrefixedUniqueId(strings.Repeat("a", rolePolicyNamePrefixMaxLen))); got > rolePolicyNameMaxLen {
+		t.Errorf("generated name length %d exceeds %d", got, rolePolicyNameMaxLen)
+	}
+}
+
 func TestValidAccountAlias(t *testing.T) {
 	t.Parallel()
 
@@ -129,3 +194,494 @@ func TestValidRolePolicyRoleName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidPolicyARN(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value: "",
+		},
+		{
+			Value: "arn:aws:iam::123456789012:policy/PermissionsBoundary", // lintignore:AWSAT005
+		},
+		{
+			Value: "arn:aws:iam::aws:policy/PowerUserAccess", // lintignore:AWSAT005
+		},
+		{
+			Value: "arn:aws-us-gov:iam::123456789012:policy/path/PermissionsBoundary", // lintignore:AWSAT005
+		},
+		{
+			Value:    "PermissionsBoundary",
+			ErrCount: 1,
+		},
+		{
+			Value:    "arn:aws:iam::123456789012:role/PermissionsBoundary", // lintignore:AWSAT005
+			ErrCount: 1,
+		},
+		{
+			Value:    "arn:aws:s3:::policy/PermissionsBoundary", // lintignore:AWSAT005
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validPolicyARN(tc.Value, "permissions_boundary")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d Policy ARN validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestValidRolePath(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value: "/",
+		},
+		{
+			Value: "/division_abc/subdivision_xyz/",
+		},
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "division_abc/",
+			ErrCount: 1,
+		},
+		{
+			Value:    "//",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/division_abc",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/division abc/",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/" + strings.Repeat("a", 511) + "/",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validRolePath(tc.Value, "path")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d Role path validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestValidRolePathNormalized(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Value     string
+		WarnCount int
+		ErrCount  int
+	}{
+		{
+			Value: "/",
+		},
+		{
+			Value: "/team/",
+		},
+		{
+			Value:     "/team",
+			WarnCount: 1,
+		},
+		{
+			Value:    "team",
+			ErrCount: 1,
+		},
+		{
+			Value:     "/team name",
+			WarnCount: 1,
+			ErrCount:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		warnings, errors := validRolePathNormalized(tc.Value, "path")
+
+		if len(warnings) != tc.WarnCount {
+			t.Fatalf("Expected %d Role path validation warnings for %q, got %d: %v", tc.WarnCount, tc.Value, len(warnings), warnings)
+		}
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d Role path validation errors for %q, got %d: %v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestValidRoleInlinePolicyDocument(t *testing.T) {
+	t.Parallel()
+
+	// policy returns an indented policy document that is n characters long once whitespace is removed.
+	policy := func(n int) string {
+		const format = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::%s"
+    }
+  ]
+}`
+		empty := len(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::"}]}`)
+		return fmt.Sprintf(format, strings.Repeat("a", n-empty))
+	}
+
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value: policy(rolePolicyDocumentMaxLen),
+		},
+		{
+			Value:    policy(rolePolicyDocumentMaxLen + 1),
+			ErrCount: 1,
+		},
+		{
+			Value:    "{",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validRoleInlinePolicyDocument(tc.Value, "policy")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d inline policy document validation errors for %d characters, got %d: %v", tc.ErrCount, len(tc.Value), len(errors), errors)
+		}
+	}
+
+	// The indented document at the limit is only valid because whitespace is not counted.
+	if v := policy(rolePolicyDocumentMaxLen); len(v) <= rolePolicyDocumentMaxLen {
+		t.Fatalf("expected indented document to be longer than %d characters, got %d", rolePolicyDocumentMaxLen, len(v))
+	}
+}
+
+func TestValidRoleInlinePolicies(t *testing.T) {
+	t.Parallel()
+
+	const policy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+
+	cases := []struct {
+		Value    map[string]interface{}
+		ErrCount int
+	}{
+		{
+			Value: map[string]interface{}{},
+		},
+		{
+			Value: map[string]interface{}{"read": policy, "write": policy},
+		},
+		{
+			Value:    map[string]interface{}{"read": "{"},
+			ErrCount: 1,
+		},
+		{
+			Value:    map[string]interface{}{"bad name": policy},
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validRoleInlinePolicies(tc.Value, "inline_policies")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d inline policies validation errors for %v, got %d: %v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestValidRoleName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value   string
+		wantErr string
+	}{
+		{
+			value: "role-name",
+		},
+		{
+			value:   "division/role-name",
+			wantErr: `"name" must not contain a slash (/), a role's path belongs in the path argument`,
+		},
+		{
+			value:   "role#name",
+			wantErr: `must match [\w+=,.@-]`,
+		},
+		{
+			value:   strings.Repeat("a", roleNameMaxLen+1),
+			wantErr: "expected length of name to be in the range (1 - 64)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, errs := validRoleName(roleNameMaxLen)(testCase.value, "name")
+
+		if testCase.wantErr == "" {
+			if len(errs) > 0 {
+				t.Errorf("%q: unexpected errors: %v", testCase.value, errs)
+			}
+			continue
+		}
+
+		if len(errs) != 1 {
+			t.Errorf("%q: expected 1 error, got %v", testCase.value, errs)
+			continue
+		}
+
+		if got := errs[0].Error(); !strings.Contains(got, testCase.wantErr) {
+			t.Errorf("%q: got error %q, want it to contain %q", testCase.value, got, testCase.wantErr)
+		}
+	}
+}
+
+func TestValidRoleNameNotReserved(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value    string
+		wantWarn string
+	}{
+		{
+			value: "role-name",
+		},
+		{
+			// Only the prefixes that AWS uses for the roles it creates are reserved.
+			value: "aws-elasticbeanstalk-ec2-role",
+		},
+		{
+			value:    "aws-service-role-example",
+			wantWarn: "aws_iam_service_linked_role",
+		},
+		{
+			value:    "AWSServiceRoleForElasticLoadBalancing",
+			wantWarn: "aws_iam_service_linked_role",
+		},
+		{
+			value:    "awsservicerolefor-example",
+			wantWarn: "aws_iam_service_linked_role",
+		},
+		{
+			value:    "AWSReservedSSO_AdministratorAccess_0123456789abcdef",
+			wantWarn: "aws_ssoadmin_permission_set",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ws, errs := validRoleNameNotReserved(testCase.value, "name")
+
+		if len(errs) > 0 {
+			t.Errorf("%q: unexpected errors: %v", testCase.value, errs)
+		}
+
+		if testCase.wantWarn == "" {
+			if len(ws) > 0 {
+				t.Errorf("%q: unexpected warnings: %v", testCase.value, ws)
+			}
+			continue
+		}
+
+		if len(ws) != 1 {
+			t.Errorf("%q: expected 1 warning, got %v", testCase.value, ws)
+			continue
+		}
+
+		if got := ws[0]; !strings.Contains(got, testCase.wantWarn) {
+			t.Errorf("%q: got warning %q, want it to contain %q", testCase.value, got, testCase.wantWarn)
+		}
+	}
+}
+
+func TestValidRolePathMessage(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"division", "/division", "division/"} {
+		_, errs := validRolePath(value, "path")
+
+		if len(errs) != 1 {
+			t.Errorf("%q: expected 1 error, got %v", value, errs)
+			continue
+		}
+
+		if got, want := errs[0].Error(), "must begin and end with a forward slash (/)"; !strings.Contains(got, want) {
+			t.Errorf("%q: got error %q, want it to contain %q", value, got, want)
+		}
+	}
+}
+
+func TestRoleServiceLinkedOnlyPrincipals(t *testing.T) {
+	t.Parallel()
+
+	services, err := roleServiceLinkedOnlyPrincipals(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":["securityhub.amazonaws.com","elasticache.amazonaws.com.cn"]},"Action":"sts:AssumeRole"}]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := services, []string{"elasticache.amazonaws.com.cn", "securityhub.amazonaws.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidRoleAssumeRolePolicy(t *testing.T) {
+	t.Parallel()
+
+	const statement = `{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}`
+
+	cases := []struct {
+		Value     string
+		WarnCount int
+		ErrCount  int
+	}{
+		{
+			Value: `{"Version":"2012-10-17","Statement":[` + statement + `]}`,
+		},
+		{
+			// A single statement need not be in an array.
+			Value: `{"Version":"2012-10-17","Statement":` + statement + `}`,
+		},
+		{
+			Value:     `{"Version":"2008-10-17","Statement":[` + statement + `]}`,
+			WarnCount: 1,
+		},
+		{
+			Value:    `{"Statement":[` + statement + `]}`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"Version":"2012-10-18","Statement":[` + statement + `]}`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"Version":20121017,"Statement":[` + statement + `]}`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"Version":"2012-10-17"}`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"Version":"2012-10-17","Statement":"sts:AssumeRole"}`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{"Version":"2012-10-18"}`,
+			ErrCount: 2,
+		},
+		{
+			Value:    `[` + statement + `]`,
+			ErrCount: 1,
+		},
+		{
+			Value:    `{`,
+			ErrCount: 1,
+		},
+		{
+			// Elastic Load Balancing only uses its service-linked role.
+			Value:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			WarnCount: 1,
+		},
+		{
+			Value:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":["securityhub.amazonaws.com","guardduty.amazonaws.com"]},"Action":"sts:AssumeRole"}]}`,
+			WarnCount: 1,
+		},
+		{
+			// The role is also trusted by a service that uses roles that you create.
+			Value: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":["elasticloadbalancing.amazonaws.com","ec2.amazonaws.com"]},"Action":"sts:AssumeRole"}]}`,
+		},
+		{
+			Value: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com","AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}]}`, // lintignore:AWSAT005
+		},
+		{
+			// GuardDuty Malware Protection for S3 assumes a role that you create.
+			Value: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"malware-protection-plan.guardduty.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+		},
+		{
+			Value:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}]}`,
+			WarnCount: 1,
+		},
+		{
+			Value:     `{"Version":"2012-10-17","Statement":[{"Sid":"Anyone","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","*"]},"Action":"sts:AssumeRole"}]}`, // lintignore:AWSAT005
+			WarnCount: 1,
+		},
+		{
+			// A condition narrows the wildcard principal.
+			Value: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-123456"}}}]}`,
+		},
+		{
+			Value: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"sts:AssumeRole"}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		warnings, errors := validRoleAssumeRolePolicy(tc.Value, "assume_role_policy")
+
+		if len(warnings) != tc.WarnCount {
+			t.Errorf("Expected %d assume role policy validation warnings for %s, got %d: %v", tc.WarnCount, tc.Value, len(warnings), warnings)
+		}
+
+		if len(errors) != tc.ErrCount {
+			t.Errorf("Expected %d assume role policy validation errors for %s, got %d: %v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestValidRoleMaxSessionDuration(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Value     int
+		WarnCount int
+		ErrCount  int
+	}{
+		{
+			Value: 3600,
+		},
+		{
+			// Sessions from role chaining are capped at one hour.
+			Value:     14400,
+			WarnCount: 1,
+		},
+		{
+			Value:    1800,
+			ErrCount: 1,
+		},
+		{
+			Value:     43201,
+			WarnCount: 1,
+			ErrCount:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		warnings, errors := validRoleMaxSessionDuration(tc.Value, "max_session_duration")
+
+		if len(warnings) != tc.WarnCount {
+			t.Errorf("Expected %d max session duration validation warnings for %d, got %d: %v", tc.WarnCount, tc.Value, len(warnings), warnings)
+		}
+
+		if len(errors) != tc.ErrCount {
+			t.Errorf("Expected %d max session duration validation errors for %d, got %d: %v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/iam"
+	awspolicy "github.com/hashicorp/awspolicyequivalence"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
@@ -24,6 +25,7 @@ const (
 
 	RoleStatusARNIsUniqueID = "uniqueid"
 	RoleStatusARNIsARN      = "arn"
+	RoleStatusExists        = "exists"
 	RoleStatusNotFound      = "notfound"
 )
 
@@ -69,3 +71,99 @@ func statusRoleCreate(ctx context.Context, conn *iam.IAM, id string) retry.State
 		return role, RoleStatusARNIsUniqueID, nil
 	}
 }
+
+// waitRoleDeleted waits until a deleted role can no longer be read, so that resources
+// that depend on the role being gone, such as a KMS key policy referencing it, do not still see it.
+func waitRoleDeleted(ctx context.Context, conn *iam.IAM, id string) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{RoleStatusExists},
+		Target:  []string{},
+		Refresh: statusRole(ctx, conn, id),
+		Timeout: propagationTimeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func statusRole(ctx context.Context, conn *iam.IAM, id string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		role, err := FindRoleByName(ctx, conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return role, RoleStatusExists, nil
+	}
+}
+
+// waitRoleTagsPropagated waits until the keys of the specified tags are returned for a newly created role.
+func waitRoleTagsPropagated(ctx context.Context, conn *iam.IAM, id string, tags []*iam.Tag) error {
+	checkFunc := func() (bool, error) {
+		role, err := FindRoleByName(ctx, conn, id)
+
+		if tfresource.NotFound(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		return roleHasTagKeys(role, tags), nil
+	}
+	opts := tfresource.WaitOpts{
+		MinTimeout: 1 * time.Second,
+	}
+
+	return tfresource.WaitUntil(ctx, propagationTimeout, checkFunc, opts)
+}
+
+// waitRoleAssumeRolePolicyPropagated waits until GetRole returns a trust policy equivalent to the specified policy.
+// An updated trust policy may not yet be in effect for all callers, such as principals in other accounts, when the update returns.
+func waitRoleAssumeRolePolicyPropagated(ctx context.Context, conn *iam.IAM, id, policy string, timeout time.Duration) error {
+	checkFunc := func() (bool, error) {
+		role, err := FindRoleByName(ctx, conn, id)
+
+		if tfresource.NotFound(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		current, err := decodeRoleAssumeRolePolicy(aws.StringValue(role.AssumeRolePolicyDocument))
+		if err != nil {
+			return false, err
+		}
+
+		return awspolicy.PoliciesAreEquivalent(current, policy)
+	}
+	opts := tfresource.WaitOpts{
+		MinTimeout: 1 * time.Second,
+	}
+
+	return tfresource.WaitUntil(ctx, timeout, checkFunc, opts)
+}
+
+func roleHasTagKeys(role *iam.Role, tags []*iam.Tag) bool {
+	keys := make(map[string]struct{}, len(role.Tags))
+	for _, tag := range role.Tags {
+		keys[aws.StringValue(tag.Key)] = struct{}{}
+	}
+
+	for _, tag := range tags {
+		if _, ok := keys[aws.StringValue(tag.Key)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -421,6 +421,80 @@ func TestSuppressEquivalentJSONDiffsWhitespaceAndNoWhitespace(t *testing.T) {
 	}
 }
 
+func TestSuppressEquivalentPolicyDiffsReordered(t *testing.T) {
+	t.Parallel()
+
+	d := new(schema.ResourceData)
+
+	// An assume role policy as configured.
+	configured := `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": ["sts:AssumeRole", "sts:TagSession"],
+    "Principal": {
+      "AWS": ["arn:aws:iam::111122223333:root", "arn:aws:iam::444455556666:root"],
+      "Service": "ec2.amazonaws.com"
+    },
+    "Condition": {
+      "StringEquals": {
+        "aws:PrincipalOrgID": ["o-aaaaaaaaaa", "o-bbbbbbbbbb"],
+        "sts:ExternalId": "example"
+      }
+    }
+  }]
+}`
+
+	testCases := []struct {
+		name     string
+		returned string
+		want     bool
+	}{
+		{
+			name: "reordered actions, principals and condition keys",
+			returned: `{"Statement":[{"Condition":{"StringEquals":{"sts:ExternalId":"example","aws:PrincipalOrgID":["o-bbbbbbbbbb","o-aaaaaaaaaa"]}},` +
+				`"Principal":{"Service":"ec2.amazonaws.com","AWS":["arn:aws:iam::444455556666:root","arn:aws:iam::111122223333:root"]},` +
+				`"Action":["sts:TagSession","sts:AssumeRole"],"Effect":"Allow"}],"Version":"2012-10-17"}`,
+			want: true,
+		},
+		{
+			name: "different action",
+			returned: `{"Statement":[{"Condition":{"StringEquals":{"sts:ExternalId":"example","aws:PrincipalOrgID":["o-bbbbbbbbbb","o-aaaaaaaaaa"]}},` +
+				`"Principal":{"Service":"ec2.amazonaws.com","AWS":["arn:aws:iam::444455556666:root","arn:aws:iam::111122223333:root"]},` +
+				`"Action":["sts:SetSourceIdentity","sts:AssumeRole"],"Effect":"Allow"}],"Version":"2012-10-17"}`,
+			want: false,
+		},
+		{
+			name: "different principal",
+			returned: `{"Statement":[{"Condition":{"StringEquals":{"sts:ExternalId":"example","aws:PrincipalOrgID":["o-bbbbbbbbbb","o-aaaaaaaaaa"]}},` +
+				`"Principal":{"Service":"ec2.amazonaws.com","AWS":["arn:aws:iam::777788889999:root","arn:aws:iam::111122223333:root"]},` +
+				`"Action":["sts:TagSession","sts:AssumeRole"],"Effect":"Allow"}],"Version":"2012-10-17"}`,
+			want: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := SuppressEquivalentPolicyDiffs("assume_role_policy", testCase.returned, configured, d); got != testCase.want {
+				t.Errorf("SuppressEquivalentPolicyDiffs = %t, want %t", got, testCase.want)
+			}
+
+			// Read keeps the configured document when AWS returns an equivalent one.
+			policyToSet, err := PolicyToSet(configured, testCase.returned)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := JSONStringsEqual(policyToSet, configured); got != testCase.want {
+				t.Errorf("PolicyToSet kept configured policy = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
 func TestSuppressEquivalentJSONOrYAMLDiffs(t *testing.T) {
 	t.Parallel()
 